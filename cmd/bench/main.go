@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"push-swap/internal/bench"
+	"push-swap/internal/solver"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// bench solves many random inputs with a strategy and reports statistics
+// on the number of operations and the time per solve
+func main() {
+	size := flag.Int("n", 100, "numbers per input")
+	trials := flag.Int("trials", 100, "number of random inputs")
+	seed := flag.Int64("seed", 1, "seed of the input generator")
+	strategy := flag.String("strategy", solver.DefaultStrategy,
+		"sorting strategy: "+strings.Join(solver.StrategyNames(), ", "))
+	jsonOutput := flag.Bool("json", false, "print the result as JSON")
+	sweep := flag.Bool("sweep", false, "search the best chunk strategy parameters for each of -sizes instead")
+	sizes := flag.String("sizes", "50,100,250,500,1000", "comma separated input sizes for -sweep")
+	flag.Parse()
+
+	if *sweep {
+		runSweep(*sizes, *trials, *seed, *jsonOutput)
+		return
+	}
+
+	result, err := bench.Run(bench.Config{
+		Size:     *size,
+		Trials:   *trials,
+		Seed:     *seed,
+		Strategy: *strategy,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *jsonOutput {
+		data, err := json.Marshal(result)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	printTable(result)
+}
+
+// printTable writes the result as an aligned table
+func printTable(r *bench.Result) {
+	fmt.Printf("strategy %s, %d numbers, %d trials, seed %d\n\n", r.Strategy, r.Size, r.Trials, r.Seed)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "\tmin\tmean\tmedian\tp95\tmax\t")
+	fmt.Fprintf(w, "ops\t%.0f\t%.1f\t%.1f\t%.0f\t%.0f\t\n",
+		r.Ops.Min, r.Ops.Mean, r.Ops.Median, r.Ops.P95, r.Ops.Max)
+	fmt.Fprintf(w, "time (ms)\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t\n",
+		r.TimeMs.Min, r.TimeMs.Mean, r.TimeMs.Median, r.TimeMs.P95, r.TimeMs.Max)
+	w.Flush()
+}
+
+// runSweep reports the best chunk parameters for every size in sizeList
+func runSweep(sizeList string, trials int, seed int64, jsonOutput bool) {
+	var sizes []int
+	for _, field := range strings.Split(sizeList, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid size %q\n", field)
+			os.Exit(1)
+		}
+		sizes = append(sizes, size)
+	}
+
+	results, err := bench.Sweep(sizes, trials, seed)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		data, err := json.Marshal(results)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("chunk parameters, %d trials, seed %d\n\n", trials, seed)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "size\tchunk size\tsplit\tmean ops\tdefault\tdefault ops\t")
+	for _, r := range results {
+		fmt.Fprintf(w, "%d\t%d\t%.2f\t%.1f\t%d / %.2f\t%.1f\t\n",
+			r.Size, r.ChunkSize, r.Split, r.MeanOps, r.DefaultChunkSize, r.DefaultSplit, r.DefaultMeanOps)
+	}
+	w.Flush()
+}
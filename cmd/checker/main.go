@@ -1,73 +1,174 @@
 package main
 
 import (
-	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
-	"strings"
+	"push-swap/internal/checker"
+	"push-swap/internal/cli"
 	"push-swap/internal/operations"
 	"push-swap/internal/parser"
-	"push-swap/internal/stack"
+	"strings"
 )
 
 func main() {
+	fs := flag.NewFlagSet("checker", flag.ContinueOnError)
+	strict := fs.Bool("strict", false, "reject operations that do not change the stacks")
+	strictFormat := fs.Bool("strict-format", false, "require exactly one instruction per newline-terminated line, like the reference checker")
+	trace := fs.Bool("trace", false, "print both stacks after every operation")
+	maxOps := fs.Int("max-ops", 0, "stop with KO once the program exceeds this many operations (0 for no limit)")
+	budget := fs.Int("budget", 0, "grade the program: pass if it sorts within this many operations")
+	gradeProfile := fs.String("grade", "", "grade the program against a profile of thresholds per input size: "+
+		strings.Join(checker.ProfileNames(), ", "))
+	jsonOutput := fs.Bool("json", false, "print a JSON report instead of OK/KO")
+	diagnose := fs.Bool("diag", false, "explain errors and exit with a distinct status per failure class")
+	domain := parser.DefaultDomain
+	fs.Var(&domain, "domain", "range of accepted integers: int32 or int64")
+
+	args, err := cli.ParseFlags(fs, os.Args[1:])
+	if err != nil {
+		if err == flag.ErrHelp {
+			return
+		}
+		os.Exit(cli.ExitUsage)
+	}
+
+	reporter := cli.NewReporter(*diagnose)
+
+	// fail reports an error that prevents the program from running
+	fail := func(code int, err error) {
+		if *jsonOutput {
+			printReport(checker.NewErrorReport(err))
+			if !*diagnose {
+				code = cli.ExitError
+			}
+			os.Exit(code)
+		}
+		reporter.Fail(code, err)
+	}
+
+	// Grading mode scores the program against a budget or a profile
+	var profile *checker.Profile
+	switch {
+	case *budget > 0 && *gradeProfile != "":
+		fail(cli.ExitUsage, errors.New("-budget and -grade cannot be used together"))
+	case *budget > 0:
+		profile = checker.BudgetProfile(*budget)
+	case *gradeProfile != "":
+		if profile, err = checker.LookupProfile(*gradeProfile); err != nil {
+			fail(cli.ExitUsage, err)
+		}
+	}
+
 	// Handle no arguments case
-	if len(os.Args) < 2 {
+	if len(args) == 0 {
 		return
 	}
-	
+
 	// Parse command line arguments
-	numbers, err := parser.ParseArguments(os.Args[1:])
+	numbers, err := parser.ParseArgumentsIn(args, domain)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Error")
-		os.Exit(1)
+		fail(cli.ExitArguments, err)
 	}
-	
+
 	// Handle empty input
 	if len(numbers) == 0 {
 		return
 	}
-	
-	// Create stacks
-	stackA := stack.NewStack(numbers)
-	stackB := stack.NewEmptyStack()
-	
-	// Read operations from stdin
-	scanner := bufio.NewScanner(os.Stdin)
-	var operationStrings []string
-	
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line != "" {
-			operationStrings = append(operationStrings, line)
+
+	// Refuse to grade before running a program that cannot be scored
+	if profile != nil {
+		if _, err := profile.Tiers(len(numbers)); err != nil {
+			fail(cli.ExitUsage, err)
 		}
 	}
-	
-	if err := scanner.Err(); err != nil {
-		fmt.Fprintln(os.Stderr, "Error")
-		os.Exit(1)
+
+	opts := checker.Options{Strict: *strict, MaxOps: *maxOps}
+	if *trace {
+		// Keep stdout parseable when the JSON report is requested
+		opts.Trace = os.Stdout
+		if *jsonOutput {
+			opts.Trace = os.Stderr
+		}
 	}
-	
-	// Parse operations
-	parsedOps, err := parser.ParseOperations(operationStrings)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "Error")
-		os.Exit(1)
-	}
-	
-	// Execute operations
-	for _, opStr := range parsedOps {
-		op := operations.Operation(opStr)
-		if err := operations.ExecuteOperation(stackA, stackB, op); err != nil {
-			fmt.Fprintln(os.Stderr, "Error")
-			os.Exit(1)
+
+	// Execute operations as they are read from stdin
+	scanner := parser.NewOperationScanner(os.Stdin, *strictFormat)
+	result := checker.Stream(numbers, scanner, opts)
+
+	var grade *checker.Grade
+	if profile != nil && result.Err == nil {
+		if grade, err = profile.Grade(result, len(numbers)); err != nil {
+			fail(cli.ExitUsage, err)
+		}
+	}
+
+	if *jsonOutput {
+		report := checker.NewReport(result)
+		report.Grade = grade
+		printReport(report)
+		if result.Err != nil {
+			if *diagnose {
+				os.Exit(exitCode(result.Err))
+			}
+			os.Exit(cli.ExitError)
+		}
+		exitGraded(grade)
+		return
+	}
+
+	if result.Err != nil {
+		var opErr *operations.OpError
+		if *strict && errors.As(result.Err, &opErr) {
+			// Strict mode always reports which operation was wasted
+			reporter.FailDetailed(cli.ExitExecution, result.Err)
 		}
+		reporter.Fail(exitCode(result.Err), result.Err)
 	}
-	
+
 	// Check if stack A is sorted and stack B is empty
-	if stackA.IsSorted() && stackB.IsEmpty() {
+	if result.Sorted() {
 		fmt.Println("OK")
 	} else {
 		fmt.Println("KO")
 	}
-}
\ No newline at end of file
+	if grade != nil {
+		fmt.Println(grade)
+	}
+	exitGraded(grade)
+}
+
+// exitGraded exits with ExitOverBudget if the program sorted its input
+// but failed its grade
+func exitGraded(grade *checker.Grade) {
+	if grade != nil && grade.OverBudget() {
+		os.Exit(cli.ExitOverBudget)
+	}
+}
+
+// exitCode returns the diagnostic exit status for an error met while
+// reading or running the program
+func exitCode(err error) int {
+	var opErr *operations.OpError
+	var invalidOp *parser.InvalidOperationError
+	var badFormat *parser.InputFormatError
+	switch {
+	case errors.As(err, &opErr):
+		return cli.ExitExecution
+	case errors.As(err, &invalidOp), errors.As(err, &badFormat):
+		return cli.ExitInstruction
+	}
+	return cli.ExitRead
+}
+
+// printReport writes report to stdout as a single line of JSON
+func printReport(report *checker.Report) {
+	data, err := json.Marshal(report)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error")
+		os.Exit(cli.ExitError)
+	}
+	fmt.Println(string(data))
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"push-swap/internal/gen"
+	"strconv"
+	"strings"
+)
+
+// gen prints seeded test inputs for push-swap and the checker
+func main() {
+	count := flag.Int("n", 100, "how many numbers to generate")
+	dist := flag.String("dist", "uniform", "distribution: "+strings.Join(gen.Names(), ", "))
+	seed := flag.Int64("seed", 1, "seed of the random generator")
+	swaps := flag.Int("swaps", gen.DefaultOptions.Swaps, "random swaps for the nearly distribution")
+	teeth := flag.Int("teeth", gen.DefaultOptions.Teeth, "ascending runs for the sawtooth distribution")
+	format := flag.String("format", "line", "output format: line (space separated), quoted (one quoted argument) or lines (one number per line)")
+	flag.Parse()
+
+	numbers, err := gen.Generate(*dist, *count, *seed, gen.Options{Swaps: *swaps, Teeth: *teeth})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fields := make([]string, len(numbers))
+	for i, num := range numbers {
+		fields[i] = strconv.Itoa(num)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	switch *format {
+	case "line":
+		fmt.Fprintln(out, strings.Join(fields, " "))
+	case "quoted":
+		fmt.Fprintf(out, "%q\n", strings.Join(fields, " "))
+	case "lines":
+		for _, field := range fields {
+			fmt.Fprintln(out, field)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format %q\n", *format)
+		os.Exit(1)
+	}
+}
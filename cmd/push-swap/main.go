@@ -1,34 +1,58 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
+	"push-swap/internal/cli"
 	"push-swap/internal/parser"
 	"push-swap/internal/solver"
+	"strings"
 )
 
 func main() {
+	fs := flag.NewFlagSet("push-swap", flag.ContinueOnError)
+	strategy := fs.String("strategy", solver.DefaultStrategy,
+		"sorting strategy: "+strings.Join(solver.StrategyNames(), ", "))
+	budget := fs.Duration("time", 0, "spend up to this long searching for a shorter program with the chosen strategy, e.g. 500ms (default: none)")
+	diagnose := fs.Bool("diag", false, "explain errors and exit with a distinct status per failure class")
+	domain := parser.DefaultDomain
+	fs.Var(&domain, "domain", "range of accepted integers: int32 or int64")
+
+	args, err := cli.ParseFlags(fs, os.Args[1:])
+	if err != nil {
+		if err == flag.ErrHelp {
+			return
+		}
+		os.Exit(cli.ExitUsage)
+	}
+
+	reporter := cli.NewReporter(*diagnose)
+
 	// Handle no arguments case
-	if len(os.Args) < 2 {
+	if len(args) == 0 {
 		return
 	}
-	
+
 	// Parse command line arguments
-	numbers, err := parser.ParseArguments(os.Args[1:])
+	numbers, err := parser.ParseArgumentsIn(args, domain)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Error")
-		os.Exit(1)
+		reporter.Fail(cli.ExitArguments, err)
 	}
-	
+
 	// Handle empty input
 	if len(numbers) == 0 {
 		return
 	}
-	
+
 	// Create solver and solve
-	s := solver.NewSolver(numbers)
-	operations := s.Solve()
-	
+	s, err := solver.NewSolverWithStrategy(numbers, *strategy)
+	if err != nil {
+		reporter.Fail(cli.ExitUsage, err)
+	}
+	operations := s.SolveWithContext(context.Background(), *budget)
+
 	// Output operations
 	for _, op := range operations {
 		fmt.Println(op)
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"push-swap/internal/solver"
+)
+
+// tablegen writes the table of optimal programs embedded in the solver
+// package. Run it through "go generate ./internal/solver".
+func main() {
+	output := flag.String("o", "", "output file (default stdout)")
+	maxSize := flag.Int("n", solver.TableMaxSize, "largest input size to include")
+	flag.Parse()
+
+	out := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := solver.WriteOptimalTable(out, *maxSize); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
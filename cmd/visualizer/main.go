@@ -6,337 +6,13 @@ import (
 	"html/template"
 	"log"
 	"net/http"
-	"push-swap/internal/operations"
 	"push-swap/internal/parser"
-	"push-swap/internal/stack"
+	"push-swap/internal/solver"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// Modified Solver that captures operations step-by-step
-type VisualizerSolver struct {
-	stackA     *stack.Stack
-	stackB     *stack.Stack
-	operations []operations.Operation
-}
-
-func NewVisualizerSolver(input []int) *VisualizerSolver {
-	return &VisualizerSolver{
-		stackA:     stack.NewStack(input),
-		stackB:     stack.NewEmptyStack(),
-		operations: make([]operations.Operation, 0),
-	}
-}
-
-func (s *VisualizerSolver) executeAndRecord(op operations.Operation) {
-	operations.ExecuteOperation(s.stackA, s.stackB, op)
-	s.operations = append(s.operations, op)
-}
-
-func (s *VisualizerSolver) Solve() []operations.Operation {
-	if s.stackA.IsSorted() {
-		return s.operations
-	}
-	
-	size := s.stackA.Size()
-	
-	switch {
-	case size <= 1:
-		return s.operations
-	case size == 2:
-		s.solveTwo()
-	case size == 3:
-		s.solveThree()
-	case size <= 5:
-		s.solveFive()
-	case size == 6:
-		s.solveSix()
-	default:
-		s.solveLarge()
-	}
-	
-	return s.operations
-}
-
-func (s *VisualizerSolver) solveTwo() {
-	first, _ := s.stackA.At(0)
-	second, _ := s.stackA.At(1)
-	if first > second {
-		s.executeAndRecord(operations.SA)
-	}
-}
-
-func (s *VisualizerSolver) solveThree() {
-	for !s.stackA.IsSorted() {
-		first, _ := s.stackA.At(0)
-		second, _ := s.stackA.At(1)
-		third, _ := s.stackA.At(2)
-		
-		if first > second && second < third && first < third {
-			s.executeAndRecord(operations.SA)
-		} else if first > second && second > third && first > third {
-			s.executeAndRecord(operations.SA)
-			s.executeAndRecord(operations.RRA)
-		} else if first > second && second < third && first > third {
-			s.executeAndRecord(operations.RA)
-		} else if first < second && second > third && first < third {
-			s.executeAndRecord(operations.SA)
-			s.executeAndRecord(operations.RA)
-		} else if first < second && second > third && first > third {
-			s.executeAndRecord(operations.RRA)
-		}
-	}
-}
-
-func (s *VisualizerSolver) solveFive() {
-	size := s.stackA.Size()
-	elementsToMove := size - 3
-	
-	for i := 0; i < elementsToMove; i++ {
-		minPos := s.findMinPosition(s.stackA)
-		s.moveToTopOptimized(s.stackA, minPos, true)
-		s.executeAndRecord(operations.PB)
-	}
-	
-	s.solveThree()
-	
-	for !s.stackB.IsEmpty() {
-		s.executeAndRecord(operations.PA)
-	}
-}
-
-func (s *VisualizerSolver) solveSix() {
-	first, _ := s.stackA.At(0)
-	second, _ := s.stackA.At(1)
-	minVal := s.findMinValue(s.stackA)
-	
-	if second == minVal || (first > second && second < s.getValueAt(s.stackA, 2)) {
-		s.executeAndRecord(operations.SA)
-	}
-	
-	for i := 0; i < 2; i++ {
-		minPos := s.findMinPosition(s.stackA)
-		s.moveToTopOptimized(s.stackA, minPos, true)
-		s.executeAndRecord(operations.PB)
-	}
-	
-	minPos := s.findMinPosition(s.stackA)
-	s.moveToTopOptimized(s.stackA, minPos, true)
-	s.executeAndRecord(operations.PB)
-	s.solveThree()
-	s.executeAndRecord(operations.PA)
-	
-	s.executeAndRecord(operations.PA)
-	s.executeAndRecord(operations.PA)
-}
-
-func (s *VisualizerSolver) solveLarge() {
-	size := s.stackA.Size()
-	
-	ranks := s.createRanks()
-	s.applyRanks(ranks)
-	
-	var chunkSize int
-	if size <= 100 {
-		chunkSize = 20
-	} else if size <= 500 {
-		chunkSize = 30
-	} else {
-		chunkSize = 45
-	}
-	
-	numChunks := (size + chunkSize - 1) / chunkSize
-	
-	for chunk := 0; chunk < numChunks; chunk++ {
-		minRange := chunk * chunkSize
-		maxRange := (chunk + 1) * chunkSize
-		if maxRange > size {
-			maxRange = size
-		}
-		
-		elementsInChunk := maxRange - minRange
-		rotations := 0
-		
-		for elementsInChunk > 0 && rotations < size*2 {
-			if s.stackA.IsEmpty() {
-				break
-			}
-			
-			top, _ := s.stackA.Top()
-			
-			if top >= minRange && top < maxRange {
-				s.executeAndRecord(operations.PB)
-				elementsInChunk--
-				
-				if s.stackB.Size() > 1 {
-					midRange := (minRange + maxRange) / 2
-					if top < midRange {
-						s.executeAndRecord(operations.RB)
-					}
-				}
-			} else {
-				s.executeAndRecord(operations.RA)
-			}
-			
-			rotations++
-		}
-	}
-	
-	for s.stackB.Size() > 0 {
-		maxPos := s.findMaxPosition(s.stackB)
-		s.moveToTopOptimized(s.stackB, maxPos, false)
-		s.executeAndRecord(operations.PA)
-	}
-}
-
-func (s *VisualizerSolver) findMinPosition(st *stack.Stack) int {
-	if st.IsEmpty() {
-		return -1
-	}
-	
-	minVal, _ := st.At(0)
-	minPos := 0
-	
-	for i := 1; i < st.Size(); i++ {
-		val, _ := st.At(i)
-		if val < minVal {
-			minVal = val
-			minPos = i
-		}
-	}
-	
-	return minPos
-}
-
-func (s *VisualizerSolver) findMinValue(st *stack.Stack) int {
-	if st.IsEmpty() {
-		return 0
-	}
-	
-	minVal, _ := st.At(0)
-	
-	for i := 1; i < st.Size(); i++ {
-		val, _ := st.At(i)
-		if val < minVal {
-			minVal = val
-		}
-	}
-	
-	return minVal
-}
-
-func (s *VisualizerSolver) getValueAt(st *stack.Stack, index int) int {
-	if index >= st.Size() {
-		return 999999
-	}
-	val, _ := st.At(index)
-	return val
-}
-
-func (s *VisualizerSolver) findMaxPosition(st *stack.Stack) int {
-	if st.IsEmpty() {
-		return -1
-	}
-	
-	maxVal, _ := st.At(0)
-	maxPos := 0
-	
-	for i := 1; i < st.Size(); i++ {
-		val, _ := st.At(i)
-		if val > maxVal {
-			maxVal = val
-			maxPos = i
-		}
-	}
-	
-	return maxPos
-}
-
-func (s *VisualizerSolver) moveToTopOptimized(st *stack.Stack, position int, isStackA bool) {
-	size := st.Size()
-	if position == 0 || size <= 1 {
-		return
-	}
-	
-	if position <= size/2 {
-		for i := 0; i < position; i++ {
-			if isStackA {
-				s.executeAndRecord(operations.RA)
-			} else {
-				s.executeAndRecord(operations.RB)
-			}
-		}
-	} else {
-		rotations := size - position
-		for i := 0; i < rotations; i++ {
-			if isStackA {
-				s.executeAndRecord(operations.RRA)
-			} else {
-				s.executeAndRecord(operations.RRB)
-			}
-		}
-	}
-}
-
-func (s *VisualizerSolver) createRanks() map[int]int {
-	data := s.stackA.ToSlice()
-	n := len(data)
-	
-	sorted := make([]int, n)
-	copy(sorted, data)
-	
-	if n > 50 {
-		s.quickSort(sorted, 0, n-1)
-	} else {
-		for i := 0; i < n-1; i++ {
-			for j := 0; j < n-i-1; j++ {
-				if sorted[j] > sorted[j+1] {
-					sorted[j], sorted[j+1] = sorted[j+1], sorted[j]
-				}
-			}
-		}
-	}
-	
-	ranks := make(map[int]int)
-	for i, val := range sorted {
-		ranks[val] = i
-	}
-	
-	return ranks
-}
-
-func (s *VisualizerSolver) quickSort(arr []int, low, high int) {
-	if low < high {
-		pi := s.partition(arr, low, high)
-		s.quickSort(arr, low, pi-1)
-		s.quickSort(arr, pi+1, high)
-	}
-}
-
-func (s *VisualizerSolver) partition(arr []int, low, high int) int {
-	pivot := arr[high]
-	i := low - 1
-	
-	for j := low; j < high; j++ {
-		if arr[j] < pivot {
-			i++
-			arr[i], arr[j] = arr[j], arr[i]
-		}
-	}
-	
-	arr[i+1], arr[high] = arr[high], arr[i+1]
-	return i + 1
-}
-
-func (s *VisualizerSolver) applyRanks(ranks map[int]int) {
-	data := s.stackA.ToSlice()
-	for i, val := range data {
-		data[i] = ranks[val]
-	}
-	*s.stackA = *stack.NewStack(data)
-}
-
 type VisualizerState struct {
 	StackA    []int  `json:"stackA"`
 	StackB    []int  `json:"stackB"`
@@ -397,6 +73,15 @@ const htmlTemplate = `<!DOCTYPE html>
         .controls { display: flex; flex-direction: column; gap: 1rem; }
         .input-row { display: flex; gap: 1rem; align-items: center; }
         
+        select {
+            padding: 0.75rem;
+            background: rgba(0,0,0,0.3);
+            border: 1px solid var(--border);
+            border-radius: 6px;
+            color: #fff;
+            font-family: inherit;
+        }
+
         input[type="text"] {
             flex: 1;
             padding: 0.75rem;
@@ -493,6 +178,10 @@ const htmlTemplate = `<!DOCTYPE html>
                     <button class="btn-danger hidden" id="stopBtn" onclick="stopSort()">Stop</button>
                 </div>
                 <div class="input-row">
+                    <label style="font-size: 0.8rem; color: var(--text-dim)">Strategy:</label>
+                    <select id="strategyInput">
+                        {{range .Strategies}}<option value="{{.}}">{{.}}</option>{{end}}
+                    </select>
                     <label style="font-size: 0.8rem; color: var(--text-dim)">Speed (ms):</label>
                     <input type="range" id="speedInput" min="1" max="500" value="50">
                     <span id="speedDisplay">50</span>
@@ -580,6 +269,7 @@ const htmlTemplate = `<!DOCTYPE html>
             document.getElementById('r100').disabled = isRunning;
             document.getElementById('r50').disabled = isRunning;
             document.getElementById('numsInput').disabled = isRunning;
+            document.getElementById('strategyInput').disabled = isRunning;
         }
 
         function stopSort() {
@@ -593,6 +283,7 @@ const htmlTemplate = `<!DOCTYPE html>
         function startSort() {
             const input = document.getElementById('numsInput').value;
             const speed = document.getElementById('speedInput').value;
+            const strategy = document.getElementById('strategyInput').value;
             const log = document.getElementById('historyLog');
 
             if (input.trim().split(/\s+/).length > 100) {
@@ -603,7 +294,8 @@ const htmlTemplate = `<!DOCTYPE html>
             log.innerHTML = '';
             toggleUI(true);
 
-            eventSource = new EventSource('/visualize?numbers=' + encodeURIComponent(input) + '&speed=' + speed);
+            eventSource = new EventSource('/visualize?numbers=' + encodeURIComponent(input) + '&speed=' + speed +
+                '&strategy=' + encodeURIComponent(strategy));
             eventSource.onmessage = (e) => render(JSON.parse(e.data));
             eventSource.addEventListener('complete', () => stopSort());
             eventSource.onerror = () => stopSort();
@@ -620,47 +312,56 @@ func main() {
 
 func handleIndex(w http.ResponseWriter, r *http.Request) {
 	tmpl, _ := template.New("index").Parse(htmlTemplate)
-	tmpl.Execute(w, nil)
+	tmpl.Execute(w, struct{ Strategies []string }{solver.StrategyNames()})
 }
 
 func handleVisualize(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "text/event-stream")
-	w.Header().Set("Cache-Control", "no-cache")
-	w.Header().Set("Connection", "keep-alive")
-
-	// Get context to detect when client stops/disconnects
-	ctx := r.Context()
-
 	numbersStr := r.URL.Query().Get("numbers")
 	speedStr := r.URL.Query().Get("speed")
 	speed := 150
 	if s, err := strconv.Atoi(speedStr); err == nil {
 		speed = s
 	}
+	strategy := r.URL.Query().Get("strategy")
+	if strategy == "" {
+		strategy = solver.DefaultStrategy
+	}
 
 	numbers, _ := parser.ParseArguments(strings.Fields(numbersStr))
-	if len(numbers) == 0 { return }
+	if len(numbers) == 0 {
+		return
+	}
 
+	// Use the same solver as push-swap so the animation matches its output
+	s, err := solver.NewSolverWithStrategy(numbers, strategy)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ops := s.Solve()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	// Get context to detect when client stops/disconnects
+	ctx := r.Context()
 	flusher := w.(http.Flusher)
-	solver := NewVisualizerSolver(numbers)
-	ops := solver.Solve()
-
-	stackA := stack.NewStack(numbers)
-	stackB := stack.NewEmptyStack()
-
-	sendState(w, flusher, stackA, stackB, "", 0)
-
-	for i, op := range ops {
-		// CHECK IF CLIENT DISCONNECTED
-		select {
-		case <-ctx.Done():
-			// User clicked stop or closed tab, stop processing
-			return
-		default:
-			time.Sleep(time.Duration(speed) * time.Millisecond)
-			operations.ExecuteOperation(stackA, stackB, op)
-			sendState(w, flusher, stackA, stackB, string(op), i+1)
+
+	solver.Trace(numbers, ops, func(step solver.Step) bool {
+		if step.Index > 0 {
+			select {
+			case <-ctx.Done():
+				// User clicked stop or closed tab, stop processing
+				return false
+			case <-time.After(time.Duration(speed) * time.Millisecond):
+			}
 		}
+		sendState(w, flusher, step)
+		return true
+	})
+	if ctx.Err() != nil {
+		return
 	}
 
 	completeData, _ := json.Marshal(map[string]interface{}{"operations": len(ops)})
@@ -668,14 +369,14 @@ func handleVisualize(w http.ResponseWriter, r *http.Request) {
 	flusher.Flush()
 }
 
-func sendState(w http.ResponseWriter, flusher http.Flusher, stackA, stackB *stack.Stack, op string, opCount int) {
+func sendState(w http.ResponseWriter, flusher http.Flusher, step solver.Step) {
 	state := VisualizerState{
-		StackA:    stackA.ToSlice(),
-		StackB:    stackB.ToSlice(),
-		Operation: op,
-		OpCount:   opCount,
+		StackA:    step.StackA,
+		StackB:    step.StackB,
+		Operation: string(step.Operation),
+		OpCount:   step.Index,
 	}
 	data, _ := json.Marshal(state)
 	fmt.Fprintf(w, "data: %s\n\n", data)
 	flusher.Flush()
-}
\ No newline at end of file
+}
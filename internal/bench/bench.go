@@ -0,0 +1,181 @@
+package bench
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"push-swap/internal/solver"
+	"sort"
+	"time"
+)
+
+// Config describes a benchmark run
+type Config struct {
+	Size     int    // numbers per input
+	Trials   int    // number of random inputs
+	Seed     int64  // seed of the input generator
+	Strategy string // registered solver strategy
+}
+
+// Stats summarises a set of measurements
+type Stats struct {
+	Min    float64 `json:"min"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P95    float64 `json:"p95"`
+	Max    float64 `json:"max"`
+}
+
+// Result is the outcome of a benchmark run
+type Result struct {
+	Size     int    `json:"size"`
+	Trials   int    `json:"trials"`
+	Seed     int64  `json:"seed"`
+	Strategy string `json:"strategy"`
+	Ops      Stats  `json:"ops"`    // operations per solve
+	TimeMs   Stats  `json:"timeMs"` // milliseconds per solve
+}
+
+// Run solves cfg.Trials random permutations of cfg.Size numbers and
+// verifies every program. It fails on the first program that does not
+// sort its input.
+func Run(cfg Config) (*Result, error) {
+	if cfg.Size < 1 || cfg.Trials < 1 {
+		return nil, fmt.Errorf("size and trials must be positive, got %d and %d", cfg.Size, cfg.Trials)
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	ops := make([]float64, cfg.Trials)
+	times := make([]float64, cfg.Trials)
+
+	for i := 0; i < cfg.Trials; i++ {
+		input := rng.Perm(cfg.Size)
+
+		start := time.Now()
+		s, err := solver.NewSolverWithStrategy(input, cfg.Strategy)
+		if err != nil {
+			return nil, err
+		}
+		program := s.Solve()
+		elapsed := time.Since(start)
+
+		if err := solver.Verify(input, program); err != nil {
+			return nil, fmt.Errorf("trial %d: strategy %s: %w", i+1, cfg.Strategy, err)
+		}
+		ops[i] = float64(len(program))
+		times[i] = float64(elapsed) / float64(time.Millisecond)
+	}
+
+	return &Result{
+		Size:     cfg.Size,
+		Trials:   cfg.Trials,
+		Seed:     cfg.Seed,
+		Strategy: cfg.Strategy,
+		Ops:      Summarize(ops),
+		TimeMs:   Summarize(times),
+	}, nil
+}
+
+// Summarize computes the statistics of values. The percentile uses the
+// nearest-rank method.
+func Summarize(values []float64) Stats {
+	if len(values) == 0 {
+		return Stats{}
+	}
+
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	rank := int(math.Ceil(0.95 * float64(n)))
+	return Stats{
+		Min:    sorted[0],
+		Mean:   sum / float64(n),
+		Median: median,
+		P95:    sorted[rank-1],
+		Max:    sorted[n-1],
+	}
+}
+
+// SweepResult compares the best chunk parameters found for a size with
+// the fixed defaults of the chunk strategy
+type SweepResult struct {
+	Size             int     `json:"size"`
+	ChunkSize        int     `json:"chunkSize"`
+	Split            float64 `json:"split"`
+	MeanOps          float64 `json:"meanOps"`
+	DefaultChunkSize int     `json:"defaultChunkSize"`
+	DefaultSplit     float64 `json:"defaultSplit"`
+	DefaultMeanOps   float64 `json:"defaultMeanOps"`
+}
+
+// Sweep tries every candidate of solver.ChunkCandidates, and the default
+// parameters, on the same trials random permutations of each size and
+// reports the parameters with the lowest mean operation count
+func Sweep(sizes []int, trials int, seed int64) ([]SweepResult, error) {
+	if trials < 1 {
+		return nil, fmt.Errorf("trials must be positive, got %d", trials)
+	}
+
+	results := make([]SweepResult, 0, len(sizes))
+	for _, size := range sizes {
+		if size < 1 {
+			return nil, fmt.Errorf("size must be positive, got %d", size)
+		}
+
+		rng := rand.New(rand.NewSource(seed))
+		inputs := make([][]int, trials)
+		for i := range inputs {
+			inputs[i] = rng.Perm(size)
+		}
+
+		meanOps := func(params solver.ChunkParams) (float64, error) {
+			total := 0
+			for i, input := range inputs {
+				program := solver.SolveChunk(input, params)
+				if err := solver.Verify(input, program); err != nil {
+					return 0, fmt.Errorf("trial %d: chunk %v: %w", i+1, params, err)
+				}
+				total += len(program)
+			}
+			return float64(total) / float64(trials), nil
+		}
+
+		defaults := solver.DefaultChunkParams(size)
+		defaultMean, err := meanOps(defaults)
+		if err != nil {
+			return nil, err
+		}
+		result := SweepResult{
+			Size:             size,
+			ChunkSize:        defaults.ChunkSize,
+			Split:            defaults.Split,
+			MeanOps:          defaultMean,
+			DefaultChunkSize: defaults.ChunkSize,
+			DefaultSplit:     defaults.Split,
+			DefaultMeanOps:   defaultMean,
+		}
+
+		for _, params := range solver.ChunkCandidates(size) {
+			mean, err := meanOps(params)
+			if err != nil {
+				return nil, err
+			}
+			if mean < result.MeanOps {
+				result.ChunkSize, result.Split, result.MeanOps = params.ChunkSize, params.Split, mean
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
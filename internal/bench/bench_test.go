@@ -0,0 +1,101 @@
+package bench
+
+import "testing"
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		expected Stats
+	}{
+		{"Empty", nil, Stats{}},
+		{"Single value", []float64{7}, Stats{Min: 7, Mean: 7, Median: 7, P95: 7, Max: 7}},
+		{"Odd count unsorted", []float64{5, 1, 3}, Stats{Min: 1, Mean: 3, Median: 3, P95: 5, Max: 5}},
+		{"Even count", []float64{4, 1, 3, 2}, Stats{Min: 1, Mean: 2.5, Median: 2.5, P95: 4, Max: 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Summarize(tt.values); got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSummarizePercentile(t *testing.T) {
+	values := make([]float64, 100)
+	for i := range values {
+		values[i] = float64(100 - i)
+	}
+
+	stats := Summarize(values)
+	if stats.P95 != 95 {
+		t.Errorf("Expected p95 95, got %v", stats.P95)
+	}
+	if values[0] != 100 {
+		t.Error("Summarize should not reorder its input")
+	}
+}
+
+func TestRun(t *testing.T) {
+	cfg := Config{Size: 20, Trials: 5, Seed: 1, Strategy: "turk"}
+
+	result, err := Run(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Trials != 5 || result.Size != 20 || result.Strategy != "turk" {
+		t.Errorf("Unexpected configuration in result: %+v", result)
+	}
+	if result.Ops.Min <= 0 || result.Ops.Min > result.Ops.Median || result.Ops.Median > result.Ops.Max {
+		t.Errorf("Inconsistent op statistics: %+v", result.Ops)
+	}
+
+	// The same seed gives the same inputs and therefore the same programs
+	again, _ := Run(cfg)
+	if again.Ops != result.Ops {
+		t.Errorf("Expected reproducible results, got %+v and %+v", result.Ops, again.Ops)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"Unknown strategy", Config{Size: 5, Trials: 1, Strategy: "bogus"}},
+		{"Unsupported size", Config{Size: 20, Trials: 1, Strategy: "small"}},
+		{"No trials", Config{Size: 5, Trials: 0, Strategy: "auto"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Run(tt.cfg); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
+
+func TestSweep(t *testing.T) {
+	results, err := Sweep([]int{30, 100}, 3, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[0].Size != 30 || results[1].Size != 100 {
+		t.Fatalf("Expected one result per size, got %+v", results)
+	}
+	for _, r := range results {
+		if r.MeanOps <= 0 || r.MeanOps > r.DefaultMeanOps {
+			t.Errorf("Best parameters should never be worse than the defaults, got %+v", r)
+		}
+		if r.ChunkSize < 1 {
+			t.Errorf("Expected a positive chunk size, got %+v", r)
+		}
+	}
+
+	if _, err := Sweep([]int{0}, 3, 1); err == nil {
+		t.Error("Expected error for a non-positive size")
+	}
+}
@@ -0,0 +1,141 @@
+package checker
+
+import (
+	"fmt"
+	"io"
+	"push-swap/internal/operations"
+	"push-swap/internal/parser"
+	"push-swap/internal/stack"
+	"strconv"
+)
+
+// Options configures how a program is checked
+type Options struct {
+	// Strict rejects operations that leave a stack unchanged
+	Strict bool
+	// Trace receives the state of both stacks after every operation when set
+	Trace io.Writer
+	// MaxOps stops the program with a KO verdict once it tries to execute
+	// more than MaxOps operations. Zero means no limit.
+	MaxOps int
+}
+
+// Result is the outcome of running a program
+type Result struct {
+	StackA     *stack.Stack
+	StackB     *stack.Stack
+	Executed   int                          // number of operations executed successfully
+	Counts     map[operations.Operation]int // executed operations by type
+	OverBudget bool                         // the program was stopped after Options.MaxOps operations
+	// Err is the first error: an *operations.OpError for an operation that
+	// failed, or, when streaming, the error from the instruction scanner
+	Err error
+}
+
+// Sorted reports whether stack A is sorted and stack B is empty
+func (r *Result) Sorted() bool {
+	return r.Err == nil && !r.OverBudget && r.StackA.IsSorted() && r.StackB.IsEmpty()
+}
+
+// Run executes a program on the given numbers, stopping at the first
+// operation that fails
+func Run(numbers []int, ops []operations.Operation, opts Options) *Result {
+	r := newRunner(numbers, opts, len(ops))
+	for _, op := range ops {
+		if !r.step(op) {
+			break
+		}
+	}
+	return r.result
+}
+
+// Stream executes instructions as scanner reads them, so memory use does
+// not depend on the length of the program. It stops at the first invalid
+// line, failing operation or, with Options.MaxOps, once the budget is
+// exceeded. Trace lines are aligned for up to MaxOps operations, since
+// the length of the program is not known in advance.
+func Stream(numbers []int, scanner *parser.OperationScanner, opts Options) *Result {
+	r := newRunner(numbers, opts, opts.MaxOps)
+	for scanner.Scan() {
+		if !r.step(operations.Operation(scanner.Op())) {
+			return r.result
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		r.result.Err = err
+	}
+	return r.result
+}
+
+// runner executes a program one operation at a time
+type runner struct {
+	result *Result
+	opts   Options
+	tracer *tracer
+}
+
+func newRunner(numbers []int, opts Options, total int) *runner {
+	r := &runner{
+		result: &Result{
+			StackA: stack.NewStack(numbers),
+			StackB: stack.NewEmptyStack(),
+			Counts: make(map[operations.Operation]int),
+		},
+		opts:   opts,
+		tracer: newTracer(opts.Trace, total),
+	}
+	r.tracer.state(0, "", r.result.StackA, r.result.StackB)
+	return r
+}
+
+// step executes op and reports whether the program may continue
+func (r *runner) step(op operations.Operation) bool {
+	result := r.result
+	index := result.Executed + 1
+	if r.opts.MaxOps > 0 && index > r.opts.MaxOps {
+		result.OverBudget = true
+		return false
+	}
+
+	var err error
+	if r.opts.Strict {
+		err = operations.ExecuteStrict(result.StackA, result.StackB, op)
+	} else {
+		err = operations.ExecuteOperation(result.StackA, result.StackB, op)
+	}
+
+	if err != nil {
+		r.tracer.failure(index, op, err)
+		result.Err = &operations.OpError{Index: index, Op: op, Err: err}
+		return false
+	}
+
+	result.Executed++
+	result.Counts[op]++
+	r.tracer.state(index, op, result.StackA, result.StackB)
+	return true
+}
+
+// tracer writes one aligned line per executed operation
+type tracer struct {
+	w     io.Writer
+	width int // width of the instruction number column
+}
+
+func newTracer(w io.Writer, total int) *tracer {
+	return &tracer{w: w, width: len(strconv.Itoa(total))}
+}
+
+func (t *tracer) state(index int, op operations.Operation, stackA, stackB *stack.Stack) {
+	if t.w == nil {
+		return
+	}
+	fmt.Fprintf(t.w, "%*d  %-3s  A: %s  B: %s\n", t.width, index, op, stackA, stackB)
+}
+
+func (t *tracer) failure(index int, op operations.Operation, err error) {
+	if t.w == nil {
+		return
+	}
+	fmt.Fprintf(t.w, "%*d  %-3s  Error: %v\n", t.width, index, op, err)
+}
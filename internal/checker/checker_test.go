@@ -0,0 +1,243 @@
+package checker
+
+import (
+	"bytes"
+	"errors"
+	"push-swap/internal/operations"
+	"push-swap/internal/parser"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		numbers  []int
+		ops      []operations.Operation
+		sorted   bool
+		executed int
+		hasError bool
+	}{
+		{
+			name:     "Sorting program",
+			numbers:  []int{3, 2, 1},
+			ops:      []operations.Operation{operations.SA, operations.RRA},
+			sorted:   true,
+			executed: 2,
+		},
+		{
+			name:     "Program that does not sort",
+			numbers:  []int{3, 2, 1},
+			ops:      []operations.Operation{operations.SA},
+			sorted:   false,
+			executed: 1,
+		},
+		{
+			name:     "Leaves elements in B",
+			numbers:  []int{1, 2, 3},
+			ops:      []operations.Operation{operations.PB},
+			sorted:   false,
+			executed: 1,
+		},
+		{
+			name:     "Push from empty B",
+			numbers:  []int{2, 1},
+			ops:      []operations.Operation{operations.SA, operations.PA, operations.RA},
+			sorted:   false,
+			executed: 1,
+			hasError: true,
+		},
+		{
+			name:     "Empty program on sorted input",
+			numbers:  []int{1, 2},
+			ops:      nil,
+			sorted:   true,
+			executed: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Run(tt.numbers, tt.ops, Options{})
+
+			if result.Sorted() != tt.sorted {
+				t.Errorf("Expected sorted %v, got %v", tt.sorted, result.Sorted())
+			}
+			if result.Executed != tt.executed {
+				t.Errorf("Expected %d executed operations, got %d", tt.executed, result.Executed)
+			}
+			if (result.Err != nil) != tt.hasError {
+				t.Errorf("Expected error %v, got %v", tt.hasError, result.Err)
+			}
+		})
+	}
+}
+
+func TestRunReportsFailingOperation(t *testing.T) {
+	ops := []operations.Operation{operations.SA, operations.PA}
+	result := Run([]int{2, 1}, ops, Options{})
+
+	var opErr *operations.OpError
+	if !errors.As(result.Err, &opErr) {
+		t.Fatalf("Expected *operations.OpError, got %v", result.Err)
+	}
+	if opErr.Index != 2 || opErr.Op != operations.PA {
+		t.Errorf("Expected failure at operation 2 (pa), got %d (%s)", opErr.Index, opErr.Op)
+	}
+}
+
+func TestRunStrict(t *testing.T) {
+	ops := []operations.Operation{operations.SA, operations.SB, operations.RRA}
+
+	if result := Run([]int{3, 2, 1}, ops, Options{}); !result.Sorted() {
+		t.Error("Lenient mode should accept the no-op sb")
+	}
+
+	result := Run([]int{3, 2, 1}, ops, Options{Strict: true})
+	if !errors.Is(result.Err, operations.ErrNoOp) {
+		t.Errorf("Strict mode should reject the no-op sb, got %v", result.Err)
+	}
+}
+
+func TestRunTrace(t *testing.T) {
+	var buf bytes.Buffer
+	ops := []operations.Operation{operations.PB, operations.SA, operations.PA, operations.RA}
+	Run([]int{3, 2, 1}, ops, Options{Trace: &buf})
+
+	expected := "0       A: [3 2 1]  B: []\n" +
+		"1  pb   A: [2 1]  B: [3]\n" +
+		"2  sa   A: [1 2]  B: [3]\n" +
+		"3  pa   A: [3 1 2]  B: []\n" +
+		"4  ra   A: [1 2 3]  B: []\n"
+	if buf.String() != expected {
+		t.Errorf("Expected trace:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestRunTraceOnError(t *testing.T) {
+	var buf bytes.Buffer
+	ops := make([]operations.Operation, 0, 10)
+	for i := 0; i < 9; i++ {
+		ops = append(ops, operations.RA)
+	}
+	ops = append(ops, operations.PA)
+	Run([]int{2, 1}, ops, Options{Trace: &buf})
+
+	lines := bytes.Split(bytes.TrimRight(buf.Bytes(), "\n"), []byte("\n"))
+	if len(lines) != 11 {
+		t.Fatalf("Expected 11 trace lines, got %d:\n%s", len(lines), buf.String())
+	}
+
+	last := string(lines[10])
+	expected := "10  pa   Error: cannot push from empty stack b"
+	if last != expected {
+		t.Errorf("Expected last line %q, got %q", expected, last)
+	}
+
+	// The instruction numbers are right-aligned
+	if first := string(lines[0]); first[:2] != " 0" {
+		t.Errorf("Expected aligned first line, got %q", first)
+	}
+}
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		sorted   bool
+		executed int
+		hasError bool
+	}{
+		{"Sorting program", "sa\nrra\n", true, 2, false},
+		{"Program that does not sort", "sa\n", false, 1, false},
+		{"Executes up to invalid line", "sa\nxx\nrra\n", false, 1, true},
+		{"Executes up to failing operation", "sa\npa\nrra\n", false, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := parser.NewOperationScanner(strings.NewReader(tt.input), false)
+			result := Stream([]int{3, 2, 1}, scanner, Options{})
+
+			if result.Sorted() != tt.sorted {
+				t.Errorf("Expected sorted %v, got %v", tt.sorted, result.Sorted())
+			}
+			if result.Executed != tt.executed {
+				t.Errorf("Expected %d executed operations, got %d", tt.executed, result.Executed)
+			}
+			if (result.Err != nil) != tt.hasError {
+				t.Errorf("Expected error %v, got %v", tt.hasError, result.Err)
+			}
+		})
+	}
+}
+
+func TestStreamReportsInvalidLine(t *testing.T) {
+	scanner := parser.NewOperationScanner(strings.NewReader("sa\n\nbad\n"), false)
+	result := Stream([]int{2, 1}, scanner, Options{})
+
+	var opErr *parser.InvalidOperationError
+	if !errors.As(result.Err, &opErr) {
+		t.Fatalf("Expected *parser.InvalidOperationError, got %v", result.Err)
+	}
+	if opErr.Line != 3 {
+		t.Errorf("Expected line 3, got %d", opErr.Line)
+	}
+}
+
+func TestMaxOps(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxOps     int
+		overBudget bool
+		sorted     bool
+	}{
+		{"Within budget", 2, false, true},
+		{"Over budget", 1, true, false},
+		{"No limit", 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := []operations.Operation{operations.SA, operations.RRA}
+			result := Run([]int{3, 2, 1}, ops, Options{MaxOps: tt.maxOps})
+
+			if result.OverBudget != tt.overBudget {
+				t.Errorf("Expected over budget %v, got %v", tt.overBudget, result.OverBudget)
+			}
+			if result.Sorted() != tt.sorted {
+				t.Errorf("Expected sorted %v, got %v", tt.sorted, result.Sorted())
+			}
+			if result.Err != nil {
+				t.Errorf("Unexpected error: %v", result.Err)
+			}
+		})
+	}
+}
+
+// endlessReader yields "ra\n" forever
+type endlessReader struct{ pos int }
+
+func (r *endlessReader) Read(p []byte) (int, error) {
+	const line = "ra\n"
+	for i := range p {
+		p[i] = line[r.pos%len(line)]
+		r.pos++
+	}
+	return len(p), nil
+}
+
+func TestStreamStopsAtBudgetWithoutReadingToEOF(t *testing.T) {
+	scanner := parser.NewOperationScanner(&endlessReader{}, true)
+	result := Stream([]int{2, 1, 3}, scanner, Options{MaxOps: 100000})
+
+	if !result.OverBudget {
+		t.Fatal("Expected the endless program to exceed the budget")
+	}
+	if result.Executed != 100000 {
+		t.Errorf("Expected 100000 executed operations, got %d", result.Executed)
+	}
+	if result.Sorted() {
+		t.Error("A program over budget should not be sorted")
+	}
+}
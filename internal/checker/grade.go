@@ -0,0 +1,166 @@
+package checker
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Tier is one grading threshold: a program of at most MaxOps operations
+// earns Points
+type Tier struct {
+	MaxOps int
+	Points int
+}
+
+// SizeLimits are the tiers that apply to inputs of up to Size numbers
+type SizeLimits struct {
+	Size  int
+	Tiers []Tier // from strictest to loosest
+}
+
+// Profile is a named set of grading thresholds per input size
+type Profile struct {
+	Name   string
+	Limits []SizeLimits // in increasing order of Size
+}
+
+// Grade is the score of a checked program
+type Grade struct {
+	Profile    string `json:"profile"`
+	Sorted     bool   `json:"sorted"`     // the program sorted the input
+	Operations int    `json:"operations"` // operations executed
+	Limit      int    `json:"limit"`      // most operations that still earn points
+	Score      int    `json:"score"`      // points of the strictest tier met, 0 on failure
+	MaxScore   int    `json:"maxScore"`
+	Passed     bool   `json:"passed"` // sorted within the limit
+}
+
+// OverBudget reports whether the program sorted the input but used too
+// many operations
+func (g *Grade) OverBudget() bool {
+	return g.Sorted && !g.Passed
+}
+
+// String summarises the grade on one line
+func (g *Grade) String() string {
+	status := "PASS"
+	if !g.Passed {
+		status = "FAIL"
+	}
+	if !g.Sorted {
+		return fmt.Sprintf("%s %d/%d (not sorted)", status, g.Score, g.MaxScore)
+	}
+	return fmt.Sprintf("%s %d/%d (%d operations, limit %d)", status, g.Score, g.MaxScore, g.Operations, g.Limit)
+}
+
+// Built-in grading profiles
+var profiles = map[string]*Profile{
+	// spec holds the pass thresholds of the push-swap subject
+	"spec": {
+		Name: "spec",
+		Limits: []SizeLimits{
+			{Size: 3, Tiers: []Tier{{MaxOps: 3, Points: 1}}},
+			{Size: 5, Tiers: []Tier{{MaxOps: 12, Points: 1}}},
+			{Size: 100, Tiers: []Tier{{MaxOps: 699, Points: 1}}},
+			{Size: 500, Tiers: []Tier{{MaxOps: 5499, Points: 1}}},
+		},
+	},
+	// 42 awards up to 5 points for 100 and 500 numbers, as in the
+	// 42 school evaluation scale
+	"42": {
+		Name: "42",
+		Limits: []SizeLimits{
+			{Size: 3, Tiers: []Tier{{MaxOps: 3, Points: 5}}},
+			{Size: 5, Tiers: []Tier{{MaxOps: 12, Points: 5}}},
+			{Size: 100, Tiers: []Tier{
+				{MaxOps: 699, Points: 5},
+				{MaxOps: 899, Points: 4},
+				{MaxOps: 1099, Points: 3},
+				{MaxOps: 1299, Points: 2},
+				{MaxOps: 1499, Points: 1},
+			}},
+			{Size: 500, Tiers: []Tier{
+				{MaxOps: 5499, Points: 5},
+				{MaxOps: 6999, Points: 4},
+				{MaxOps: 8499, Points: 3},
+				{MaxOps: 9999, Points: 2},
+				{MaxOps: 11499, Points: 1},
+			}},
+		},
+	},
+}
+
+// LookupProfile returns the built-in grading profile with the given name
+func LookupProfile(name string) (*Profile, error) {
+	p, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown grading profile %q", name)
+	}
+	return p, nil
+}
+
+// ProfileNames returns the names of the built-in grading profiles
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BudgetProfile passes any sorting program of at most maxOps operations,
+// whatever the input size
+func BudgetProfile(maxOps int) *Profile {
+	return &Profile{
+		Name:   "budget",
+		Limits: []SizeLimits{{Size: math.MaxInt, Tiers: []Tier{{MaxOps: maxOps, Points: 1}}}},
+	}
+}
+
+// Tiers returns the thresholds for n numbers: those of the smallest size
+// in the profile that is at least n. It fails for inputs larger than every
+// size of the profile, which can therefore not be graded.
+func (p *Profile) Tiers(n int) ([]Tier, error) {
+	for _, limits := range p.Limits {
+		if n <= limits.Size {
+			return limits.Tiers, nil
+		}
+	}
+	if len(p.Limits) == 0 {
+		return nil, fmt.Errorf("grading profile %s has no thresholds", p.Name)
+	}
+	largest := p.Limits[len(p.Limits)-1].Size
+	return nil, fmt.Errorf("grading profile %s has no thresholds for %d numbers (at most %d)", p.Name, n, largest)
+}
+
+// Grade scores a result for an input of n numbers
+func (p *Profile) Grade(result *Result, n int) (*Grade, error) {
+	tiers, err := p.Tiers(n)
+	if err != nil {
+		return nil, err
+	}
+
+	grade := &Grade{
+		Profile:    p.Name,
+		Sorted:     result.Sorted(),
+		Operations: result.Executed,
+		Limit:      tiers[len(tiers)-1].MaxOps,
+	}
+	for _, tier := range tiers {
+		grade.MaxScore = max(grade.MaxScore, tier.Points)
+	}
+	if !grade.Sorted {
+		return grade, nil
+	}
+
+	for _, tier := range tiers {
+		if grade.Operations <= tier.MaxOps {
+			grade.Score = tier.Points
+			grade.Passed = true
+			break
+		}
+	}
+	return grade, nil
+}
@@ -0,0 +1,127 @@
+package checker
+
+import (
+	"push-swap/internal/stack"
+	"testing"
+)
+
+// sortedResult returns the result of a program that sorted its input in
+// the given number of operations
+func sortedResult(executed int) *Result {
+	return &Result{
+		StackA:   stack.NewStack([]int{1, 2, 3}),
+		StackB:   stack.NewEmptyStack(),
+		Executed: executed,
+	}
+}
+
+func TestProfileGrade(t *testing.T) {
+	tests := []struct {
+		name       string
+		profile    string
+		size       int
+		executed   int
+		score      int
+		passed     bool
+		overBudget bool
+	}{
+		{"Spec 3 within limit", "spec", 3, 3, 1, true, false},
+		{"Spec 3 over limit", "spec", 3, 4, 0, false, true},
+		{"Spec 5 at limit", "spec", 5, 12, 1, true, false},
+		{"Spec 100 below 700", "spec", 100, 699, 1, true, false},
+		{"Spec 100 at 700", "spec", 100, 700, 0, false, true},
+		{"Spec 500 below 5500", "spec", 500, 5499, 1, true, false},
+		{"Smaller size uses next thresholds", "spec", 4, 12, 1, true, false},
+		{"42 100 top tier", "42", 100, 650, 5, true, false},
+		{"42 100 middle tier", "42", 100, 1000, 3, true, false},
+		{"42 100 last tier", "42", 100, 1499, 1, true, false},
+		{"42 100 over every tier", "42", 100, 1500, 0, false, true},
+		{"42 500 second tier", "42", 500, 6000, 4, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := LookupProfile(tt.profile)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			grade, err := profile.Grade(sortedResult(tt.executed), tt.size)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if grade.Score != tt.score {
+				t.Errorf("Expected score %d, got %d", tt.score, grade.Score)
+			}
+			if grade.Passed != tt.passed {
+				t.Errorf("Expected passed %v, got %v", tt.passed, grade.Passed)
+			}
+			if grade.OverBudget() != tt.overBudget {
+				t.Errorf("Expected over budget %v, got %v", tt.overBudget, grade.OverBudget())
+			}
+		})
+	}
+}
+
+func TestGradeUnsorted(t *testing.T) {
+	profile, _ := LookupProfile("42")
+	result := &Result{
+		StackA:   stack.NewStack([]int{2, 1, 3}),
+		StackB:   stack.NewEmptyStack(),
+		Executed: 1,
+	}
+
+	grade, err := profile.Grade(result, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if grade.Passed || grade.Score != 0 || grade.OverBudget() {
+		t.Errorf("Unsorted program should fail without being over budget, got %+v", grade)
+	}
+	if grade.String() != "FAIL 0/5 (not sorted)" {
+		t.Errorf("Unexpected summary %q", grade.String())
+	}
+}
+
+func TestGradeUnknownSize(t *testing.T) {
+	profile, _ := LookupProfile("spec")
+	if _, err := profile.Grade(sortedResult(10), 501); err == nil {
+		t.Error("Expected error for a size beyond the profile but got none")
+	}
+	if _, err := profile.Tiers(501); err == nil {
+		t.Error("Expected no tiers for a size beyond the profile")
+	}
+	if tiers, err := profile.Tiers(500); err != nil || len(tiers) != 1 || tiers[0].MaxOps != 5499 {
+		t.Errorf("Expected the 500 number tier, got %v, %v", tiers, err)
+	}
+}
+
+func TestBudgetProfile(t *testing.T) {
+	profile := BudgetProfile(10)
+
+	grade, err := profile.Grade(sortedResult(10), 1000)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !grade.Passed {
+		t.Error("Expected a program at the budget to pass")
+	}
+	if grade.String() != "PASS 1/1 (10 operations, limit 10)" {
+		t.Errorf("Unexpected summary %q", grade.String())
+	}
+
+	grade, _ = profile.Grade(sortedResult(11), 1000)
+	if !grade.OverBudget() {
+		t.Error("Expected a program over the budget to be reported as such")
+	}
+}
+
+func TestLookupProfile(t *testing.T) {
+	if _, err := LookupProfile("unknown"); err == nil {
+		t.Error("Expected error for unknown profile but got none")
+	}
+	names := ProfileNames()
+	if len(names) != 2 || names[0] != "42" || names[1] != "spec" {
+		t.Errorf("Expected [42 spec], got %v", names)
+	}
+}
@@ -0,0 +1,109 @@
+package checker
+
+import (
+	"errors"
+	"push-swap/internal/operations"
+	"push-swap/internal/parser"
+)
+
+// Verdicts reported by the checker
+const (
+	VerdictOK    = "OK"
+	VerdictKO    = "KO"
+	VerdictError = "Error"
+)
+
+// Report is a machine-readable summary of a checked program
+type Report struct {
+	Verdict    string         `json:"verdict"`
+	Operations int            `json:"operations"`
+	Counts     map[string]int `json:"counts"`
+	Error      *ErrorDetail   `json:"error,omitempty"`
+	OverBudget bool           `json:"overBudget,omitempty"` // stopped after the operation budget
+	Grade      *Grade         `json:"grade,omitempty"`      // set in grading mode
+	StackA     []int          `json:"stackA"`
+	StackB     []int          `json:"stackB"`
+	Sorted     bool           `json:"sorted"` // whether stack A is in ascending order
+}
+
+// ErrorDetail describes the first error of a program
+type ErrorDetail struct {
+	Index     int    `json:"index,omitempty"` // 1-based position of the failing operation
+	Operation string `json:"operation,omitempty"`
+	Line      int    `json:"line,omitempty"`     // 1-based input line of an invalid or malformed instruction
+	Argument  int    `json:"argument,omitempty"` // 1-based command line argument of an invalid number
+	Column    int    `json:"column,omitempty"`   // 1-based column of the number within its argument
+	Token     string `json:"token,omitempty"`    // the offending number or instruction as written
+	Reason    string `json:"reason"`
+}
+
+// NewReport summarises the result of Run
+func NewReport(result *Result) *Report {
+	report := &Report{
+		Verdict:    VerdictKO,
+		Operations: result.Executed,
+		Counts:     make(map[string]int, len(operations.AllOperations)),
+		StackA:     result.StackA.ToSlice(),
+		StackB:     result.StackB.ToSlice(),
+		Sorted:     result.StackA.IsSorted(),
+		OverBudget: result.OverBudget,
+	}
+	for _, op := range operations.AllOperations {
+		report.Counts[string(op)] = result.Counts[op]
+	}
+
+	switch {
+	case result.Err != nil:
+		report.Verdict = VerdictError
+		report.Error = newErrorDetail(result.Err)
+	case result.Sorted():
+		report.Verdict = VerdictOK
+	}
+	return report
+}
+
+// NewErrorReport reports a failure that happened before the program could
+// run, such as invalid arguments or an unreadable instruction
+func NewErrorReport(err error) *Report {
+	report := &Report{
+		Verdict: VerdictError,
+		Counts:  make(map[string]int, len(operations.AllOperations)),
+		Error:   newErrorDetail(err),
+		StackA:  []int{},
+		StackB:  []int{},
+	}
+	for _, op := range operations.AllOperations {
+		report.Counts[string(op)] = 0
+	}
+	return report
+}
+
+func newErrorDetail(err error) *ErrorDetail {
+	var (
+		opErr      *operations.OpError
+		invalidOp  *parser.InvalidOperationError
+		badFormat  *parser.InputFormatError
+		invalidInt *parser.InvalidIntegerError
+		outOfRange *parser.OutOfRangeError
+		duplicate  *parser.DuplicateError
+	)
+	switch {
+	case errors.As(err, &opErr):
+		return &ErrorDetail{
+			Index:     opErr.Index,
+			Operation: string(opErr.Op),
+			Reason:    opErr.Err.Error(),
+		}
+	case errors.As(err, &invalidOp):
+		return &ErrorDetail{Line: invalidOp.Line, Token: invalidOp.Token, Reason: err.Error()}
+	case errors.As(err, &badFormat):
+		return &ErrorDetail{Line: badFormat.Line, Reason: err.Error()}
+	case errors.As(err, &invalidInt):
+		return &ErrorDetail{Argument: invalidInt.Arg, Column: invalidInt.Column, Token: invalidInt.Token, Reason: err.Error()}
+	case errors.As(err, &outOfRange):
+		return &ErrorDetail{Argument: outOfRange.Arg, Column: outOfRange.Column, Token: outOfRange.Token, Reason: err.Error()}
+	case errors.As(err, &duplicate):
+		return &ErrorDetail{Argument: duplicate.Arg, Column: duplicate.Column, Token: duplicate.Token, Reason: err.Error()}
+	}
+	return &ErrorDetail{Reason: err.Error()}
+}
@@ -0,0 +1,144 @@
+package checker
+
+import (
+	"encoding/json"
+	"errors"
+	"push-swap/internal/operations"
+	"push-swap/internal/parser"
+	"reflect"
+	"testing"
+)
+
+func TestNewReport(t *testing.T) {
+	ops := []operations.Operation{operations.PB, operations.SA, operations.PA, operations.RA}
+	report := NewReport(Run([]int{3, 2, 1}, ops, Options{}))
+
+	if report.Verdict != VerdictOK {
+		t.Errorf("Expected verdict OK, got %s", report.Verdict)
+	}
+	if report.Operations != 4 {
+		t.Errorf("Expected 4 operations, got %d", report.Operations)
+	}
+	if report.Counts["pb"] != 1 || report.Counts["ra"] != 1 || report.Counts["rrr"] != 0 {
+		t.Errorf("Unexpected counts %v", report.Counts)
+	}
+	if len(report.Counts) != len(operations.AllOperations) {
+		t.Errorf("Expected a count for every operation, got %v", report.Counts)
+	}
+	if !reflect.DeepEqual(report.StackA, []int{1, 2, 3}) || len(report.StackB) != 0 {
+		t.Errorf("Unexpected final stacks A=%v B=%v", report.StackA, report.StackB)
+	}
+	if !report.Sorted || report.Error != nil {
+		t.Errorf("Expected sorted report without error, got %+v", report)
+	}
+}
+
+func TestNewReportKO(t *testing.T) {
+	// A is sorted but B is not empty
+	report := NewReport(Run([]int{1, 2, 3}, []operations.Operation{operations.PB}, Options{}))
+
+	if report.Verdict != VerdictKO {
+		t.Errorf("Expected verdict KO, got %s", report.Verdict)
+	}
+	if !report.Sorted {
+		t.Error("Expected stack A to be reported as sorted")
+	}
+}
+
+func TestNewReportOverBudget(t *testing.T) {
+	ops := []operations.Operation{operations.SA, operations.RRA}
+	report := NewReport(Run([]int{3, 2, 1}, ops, Options{MaxOps: 1}))
+
+	if report.Verdict != VerdictKO {
+		t.Errorf("Expected verdict KO, got %s", report.Verdict)
+	}
+	if !report.OverBudget || report.Operations != 1 {
+		t.Errorf("Expected over budget after 1 operation, got %v after %d", report.OverBudget, report.Operations)
+	}
+}
+
+func TestNewReportExecutionError(t *testing.T) {
+	ops := []operations.Operation{operations.SA, operations.PA}
+	report := NewReport(Run([]int{2, 1}, ops, Options{}))
+
+	if report.Verdict != VerdictError {
+		t.Errorf("Expected verdict Error, got %s", report.Verdict)
+	}
+	expected := &ErrorDetail{Index: 2, Operation: "pa", Reason: "cannot push from empty stack b"}
+	if !reflect.DeepEqual(report.Error, expected) {
+		t.Errorf("Expected error %+v, got %+v", expected, report.Error)
+	}
+	if report.Operations != 1 {
+		t.Errorf("Expected 1 executed operation, got %d", report.Operations)
+	}
+}
+
+func TestNewErrorReport(t *testing.T) {
+	report := NewErrorReport(errors.New("invalid integer: abc"))
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	json.Unmarshal(data, &decoded)
+
+	if decoded["verdict"] != "Error" {
+		t.Errorf("Expected verdict Error, got %v", decoded["verdict"])
+	}
+	errDetail, _ := decoded["error"].(map[string]interface{})
+	if errDetail["reason"] != "invalid integer: abc" {
+		t.Errorf("Expected reason to be reported, got %v", decoded["error"])
+	}
+	if _, ok := errDetail["index"]; ok {
+		t.Error("Index should be omitted when unknown")
+	}
+	if a, ok := decoded["stackA"].([]interface{}); !ok || len(a) != 0 {
+		t.Errorf("Expected empty stackA array, got %v", decoded["stackA"])
+	}
+}
+
+func TestNewErrorReportParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected ErrorDetail
+	}{
+		{
+			name:     "Invalid operation",
+			err:      &parser.InvalidOperationError{Token: "sx", Line: 3},
+			expected: ErrorDetail{Line: 3, Token: "sx"},
+		},
+		{
+			name:     "Malformed line",
+			err:      &parser.InputFormatError{Line: 2, Reason: "blank line"},
+			expected: ErrorDetail{Line: 2},
+		},
+		{
+			name:     "Invalid integer",
+			err:      &parser.InvalidIntegerError{Token: "4x", Arg: 2, Column: 5},
+			expected: ErrorDetail{Argument: 2, Column: 5, Token: "4x"},
+		},
+		{
+			name:     "Out of range",
+			err:      &parser.OutOfRangeError{Token: "2147483648", Arg: 1, Column: 1},
+			expected: ErrorDetail{Argument: 1, Column: 1, Token: "2147483648"},
+		},
+		{
+			name:     "Duplicate",
+			err:      &parser.DuplicateError{Value: 3, Token: "3", Arg: 1, Column: 3},
+			expected: ErrorDetail{Argument: 1, Column: 3, Token: "3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detail := NewErrorReport(tt.err).Error
+			tt.expected.Reason = tt.err.Error()
+			if *detail != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, *detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"flag"
+	"strings"
+)
+
+// ParseFlags parses the flags at the start of args with fs and returns the
+// remaining arguments. Unlike fs.Parse it stops at negative numbers, so
+// "push-swap -5 3 2" treats -5 as input instead of an unknown flag.
+func ParseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	end := 0
+	for end < len(args) {
+		arg := args[end]
+		if arg == "--" {
+			end++
+			break
+		}
+		if !isFlag(arg) {
+			break
+		}
+		end++
+
+		// A non-boolean flag given as "-name value" also consumes the value
+		name := strings.TrimLeft(arg, "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+		if f := fs.Lookup(name); f != nil && !isBoolFlag(f) {
+			end++
+		}
+	}
+	if end > len(args) {
+		end = len(args)
+	}
+
+	if err := fs.Parse(args[:end]); err != nil {
+		return nil, err
+	}
+
+	rest := append([]string{}, fs.Args()...)
+	return append(rest, args[end:]...), nil
+}
+
+// isFlag reports whether arg looks like a flag rather than a number
+func isFlag(arg string) bool {
+	if len(arg) < 2 || arg[0] != '-' {
+		return false
+	}
+	c := arg[1]
+	if c == '-' && len(arg) > 2 {
+		c = arg[2]
+	}
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isBoolFlag(f *flag.Flag) bool {
+	bf, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && bf.IsBoolFlag()
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		strategy string
+		verbose  bool
+		expected []string
+	}{
+		{
+			name:     "No flags",
+			args:     []string{"3", "2", "1"},
+			strategy: "auto",
+			expected: []string{"3", "2", "1"},
+		},
+		{
+			name:     "Negative numbers are not flags",
+			args:     []string{"-5", "3", "-2"},
+			strategy: "auto",
+			expected: []string{"-5", "3", "-2"},
+		},
+		{
+			name:     "Negative number in a single argument",
+			args:     []string{"-5 3 -2"},
+			strategy: "auto",
+			expected: []string{"-5 3 -2"},
+		},
+		{
+			name:     "Value flag with separate value",
+			args:     []string{"-strategy", "turk", "-5", "3"},
+			strategy: "turk",
+			expected: []string{"-5", "3"},
+		},
+		{
+			name:     "Value flag with equals",
+			args:     []string{"--strategy=chunk", "3 2 1"},
+			strategy: "chunk",
+			expected: []string{"3 2 1"},
+		},
+		{
+			name:     "Bool flag",
+			args:     []string{"-v", "2", "1"},
+			strategy: "auto",
+			verbose:  true,
+			expected: []string{"2", "1"},
+		},
+		{
+			name:     "Double dash terminator",
+			args:     []string{"-v", "--", "-strategy"},
+			strategy: "auto",
+			verbose:  true,
+			expected: []string{"-strategy"},
+		},
+		{
+			name:     "Flags after numbers are arguments",
+			args:     []string{"1", "-v"},
+			strategy: "auto",
+			expected: []string{"1", "-v"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			strategy := fs.String("strategy", "auto", "")
+			verbose := fs.Bool("v", false, "")
+
+			rest, err := ParseFlags(fs, tt.args)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if *strategy != tt.strategy {
+				t.Errorf("Expected strategy %q, got %q", tt.strategy, *strategy)
+			}
+			if *verbose != tt.verbose {
+				t.Errorf("Expected verbose %v, got %v", tt.verbose, *verbose)
+			}
+			if !reflect.DeepEqual(rest, tt.expected) {
+				t.Errorf("Expected remaining args %v, got %v", tt.expected, rest)
+			}
+		})
+	}
+}
+
+func TestParseFlagsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"Unknown flag", []string{"-unknown", "1"}},
+		{"Missing value", []string{"-strategy"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.String("strategy", "auto", "")
+
+			if _, err := ParseFlags(fs, tt.args); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
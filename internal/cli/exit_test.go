@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestReporterFail(t *testing.T) {
+	tests := []struct {
+		name     string
+		diagnose bool
+		detailed bool
+		output   string
+		code     int
+	}{
+		{"Default prints bare Error", false, false, "Error\n", ExitError},
+		{"Diagnostic prints cause", true, false, "Error: bad token\n", ExitArguments},
+		{"Detailed without diagnostic keeps exit 1", false, true, "Error: bad token\n", ExitError},
+		{"Detailed with diagnostic", true, true, "Error: bad token\n", ExitArguments},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			code := -1
+			r := &Reporter{Diagnose: tt.diagnose, Output: &out, Exit: func(c int) { code = c }}
+
+			if tt.detailed {
+				r.FailDetailed(ExitArguments, errors.New("bad token"))
+			} else {
+				r.Fail(ExitArguments, errors.New("bad token"))
+			}
+
+			if out.String() != tt.output {
+				t.Errorf("Expected output %q, got %q", tt.output, out.String())
+			}
+			if code != tt.code {
+				t.Errorf("Expected exit code %d, got %d", tt.code, code)
+			}
+		})
+	}
+}
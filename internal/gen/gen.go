@@ -0,0 +1,160 @@
+package gen
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Options tunes the distributions that take a parameter
+type Options struct {
+	Swaps int // random swaps applied by "nearly"
+	Teeth int // ascending runs produced by "sawtooth"
+}
+
+// DefaultOptions are used by the gen command unless overridden
+var DefaultOptions = Options{Swaps: 5, Teeth: 4}
+
+// generator produces n distinct integers
+type generator func(rng *rand.Rand, n int, opts Options) ([]int, error)
+
+// distributions maps each distribution name to its generator. Apart from
+// "int32", every distribution arranges the values 1..n.
+var distributions = map[string]generator{
+	"uniform":    uniform,
+	"nearly":     nearlySorted,
+	"reversed":   reversed,
+	"rotated":    rotated,
+	"sawtooth":   sawtooth,
+	"organ-pipe": organPipe,
+	"int32":      fullInt32,
+}
+
+// Names returns the available distributions in alphabetical order
+func Names() []string {
+	names := make([]string, 0, len(distributions))
+	for name := range distributions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Generate returns n distinct integers following the named distribution.
+// The same seed always gives the same numbers.
+func Generate(name string, n int, seed int64, opts Options) ([]int, error) {
+	g, ok := distributions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown distribution %q", name)
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("count must not be negative, got %d", n)
+	}
+	return g(rand.New(rand.NewSource(seed)), n, opts)
+}
+
+// ascending returns 1..n
+func ascending(n int) []int {
+	numbers := make([]int, n)
+	for i := range numbers {
+		numbers[i] = i + 1
+	}
+	return numbers
+}
+
+// uniform is a uniformly random permutation
+func uniform(rng *rand.Rand, n int, _ Options) ([]int, error) {
+	numbers := ascending(n)
+	rng.Shuffle(n, func(i, j int) { numbers[i], numbers[j] = numbers[j], numbers[i] })
+	return numbers, nil
+}
+
+// nearlySorted is the sorted sequence after opts.Swaps random swaps
+func nearlySorted(rng *rand.Rand, n int, opts Options) ([]int, error) {
+	if opts.Swaps < 0 {
+		return nil, fmt.Errorf("swaps must not be negative, got %d", opts.Swaps)
+	}
+	numbers := ascending(n)
+	if n < 2 {
+		return numbers, nil
+	}
+	for k := 0; k < opts.Swaps; k++ {
+		i, j := rng.Intn(n), rng.Intn(n)
+		numbers[i], numbers[j] = numbers[j], numbers[i]
+	}
+	return numbers, nil
+}
+
+// reversed is the sequence in descending order
+func reversed(_ *rand.Rand, n int, _ Options) ([]int, error) {
+	numbers := make([]int, n)
+	for i := range numbers {
+		numbers[i] = n - i
+	}
+	return numbers, nil
+}
+
+// rotated is the sorted sequence rotated by a random non-zero offset, so
+// it is sorted except for a single descent
+func rotated(rng *rand.Rand, n int, _ Options) ([]int, error) {
+	numbers := ascending(n)
+	if n < 2 {
+		return numbers, nil
+	}
+	offset := 1 + rng.Intn(n-1)
+	return append(numbers[offset:], numbers[:offset]...), nil
+}
+
+// sawtooth deals the sorted values into opts.Teeth ascending runs, each
+// spanning the whole range: 1 4 7 2 5 8 3 6 9 for 9 numbers and 3 teeth
+func sawtooth(_ *rand.Rand, n int, opts Options) ([]int, error) {
+	if opts.Teeth < 1 {
+		return nil, fmt.Errorf("teeth must be positive, got %d", opts.Teeth)
+	}
+	numbers := make([]int, 0, n)
+	for start := 1; start <= min(opts.Teeth, n); start++ {
+		for v := start; v <= n; v += opts.Teeth {
+			numbers = append(numbers, v)
+		}
+	}
+	return numbers, nil
+}
+
+// organPipe rises through the odd values and falls through the even
+// ones: 1 3 5 6 4 2
+func organPipe(_ *rand.Rand, n int, _ Options) ([]int, error) {
+	numbers := make([]int, 0, n)
+	for v := 1; v <= n; v += 2 {
+		numbers = append(numbers, v)
+	}
+	for v := n - n%2; v >= 2; v -= 2 {
+		numbers = append(numbers, v)
+	}
+	return numbers, nil
+}
+
+// fullInt32 draws distinct values from the whole int32 range, always
+// including both extremes when n >= 2
+func fullInt32(rng *rand.Rand, n int, _ Options) ([]int, error) {
+	if int64(n) > 1<<32 {
+		return nil, fmt.Errorf("int32 range holds at most %d distinct values", int64(1)<<32)
+	}
+
+	numbers := make([]int, 0, n)
+	seen := make(map[int]bool, n)
+	if n >= 2 {
+		numbers = append(numbers, math.MinInt32, math.MaxInt32)
+		seen[math.MinInt32], seen[math.MaxInt32] = true, true
+	}
+	for len(numbers) < n {
+		v := int(rng.Int63n(1<<32) + math.MinInt32)
+		if !seen[v] {
+			seen[v] = true
+			numbers = append(numbers, v)
+		}
+	}
+
+	rng.Shuffle(n, func(i, j int) { numbers[i], numbers[j] = numbers[j], numbers[i] })
+	return numbers, nil
+}
@@ -0,0 +1,167 @@
+package gen
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// isPermutation reports whether numbers holds exactly 1..n
+func isPermutation(numbers []int) bool {
+	sorted := append([]int{}, numbers...)
+	sort.Ints(sorted)
+	for i, v := range sorted {
+		if v != i+1 {
+			return false
+		}
+	}
+	return true
+}
+
+// descents counts the positions where the next number is smaller
+func descents(numbers []int) int {
+	count := 0
+	for i := 1; i < len(numbers); i++ {
+		if numbers[i] < numbers[i-1] {
+			count++
+		}
+	}
+	return count
+}
+
+func TestGenerateShapes(t *testing.T) {
+	tests := []struct {
+		name     string
+		dist     string
+		n        int
+		opts     Options
+		expected []int
+	}{
+		{"Reversed", "reversed", 5, DefaultOptions, []int{5, 4, 3, 2, 1}},
+		{"Sawtooth", "sawtooth", 9, Options{Teeth: 3}, []int{1, 4, 7, 2, 5, 8, 3, 6, 9}},
+		{"Sawtooth uneven", "sawtooth", 7, Options{Teeth: 3}, []int{1, 4, 7, 2, 5, 3, 6}},
+		{"Sawtooth more teeth than numbers", "sawtooth", 2, Options{Teeth: 5}, []int{1, 2}},
+		{"Organ pipe even", "organ-pipe", 6, DefaultOptions, []int{1, 3, 5, 6, 4, 2}},
+		{"Organ pipe odd", "organ-pipe", 5, DefaultOptions, []int{1, 3, 5, 4, 2}},
+		{"Nearly sorted without swaps", "nearly", 4, Options{}, []int{1, 2, 3, 4}},
+		{"Empty", "uniform", 0, DefaultOptions, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			numbers, err := Generate(tt.dist, tt.n, 1, tt.opts)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(numbers, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, numbers)
+			}
+		})
+	}
+}
+
+func TestGeneratePermutations(t *testing.T) {
+	for _, name := range Names() {
+		if name == "int32" {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			for _, n := range []int{1, 2, 10, 101} {
+				numbers, err := Generate(name, n, 42, DefaultOptions)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if len(numbers) != n || !isPermutation(numbers) {
+					t.Errorf("Expected a permutation of 1..%d, got %v", n, numbers)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateRotated(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		numbers, _ := Generate("rotated", 10, seed, DefaultOptions)
+		if descents(numbers) != 1 {
+			t.Errorf("Expected a single descent, got %v", numbers)
+		}
+	}
+}
+
+func TestGenerateNearlySorted(t *testing.T) {
+	numbers, _ := Generate("nearly", 100, 7, Options{Swaps: 3})
+
+	displaced := 0
+	for i, v := range numbers {
+		if v != i+1 {
+			displaced++
+		}
+	}
+	if displaced == 0 || displaced > 6 {
+		t.Errorf("Expected 1 to 6 displaced numbers after 3 swaps, got %d", displaced)
+	}
+}
+
+func TestGenerateInt32(t *testing.T) {
+	numbers, err := Generate("int32", 50, 3, DefaultOptions)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	seen := make(map[int]bool)
+	hasMin, hasMax, hasNegative := false, false, false
+	for _, v := range numbers {
+		if seen[v] {
+			t.Fatalf("Duplicate value %d", v)
+		}
+		seen[v] = true
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			t.Fatalf("Value %d outside the int32 range", v)
+		}
+		hasMin = hasMin || v == math.MinInt32
+		hasMax = hasMax || v == math.MaxInt32
+		hasNegative = hasNegative || v < 0
+	}
+	if len(numbers) != 50 || !hasMin || !hasMax || !hasNegative {
+		t.Errorf("Expected 50 values including both extremes, got %v", numbers)
+	}
+}
+
+func TestGenerateIsSeeded(t *testing.T) {
+	for _, name := range Names() {
+		a, _ := Generate(name, 30, 9, DefaultOptions)
+		b, _ := Generate(name, 30, 9, DefaultOptions)
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("%s: expected the same numbers for the same seed", name)
+		}
+	}
+
+	a, _ := Generate("uniform", 30, 1, DefaultOptions)
+	b, _ := Generate("uniform", 30, 2, DefaultOptions)
+	if reflect.DeepEqual(a, b) {
+		t.Error("Expected different seeds to give different permutations")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		dist string
+		n    int
+		opts Options
+	}{
+		{"Unknown distribution", "gaussian", 5, DefaultOptions},
+		{"Negative count", "uniform", -1, DefaultOptions},
+		{"Negative swaps", "nearly", 5, Options{Swaps: -1}},
+		{"No teeth", "sawtooth", 5, Options{Teeth: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Generate(tt.dist, tt.n, 1, tt.opts); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
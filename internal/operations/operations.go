@@ -37,37 +37,58 @@ var ValidOperations = map[string]Operation{
 	"rrr": RRR,
 }
 
-// ExecuteOperation executes a single operation on the given stacks
+// AllOperations lists every valid operation in a fixed order, for callers
+// that need to enumerate them deterministically
+var AllOperations = []Operation{SA, SB, SS, PA, PB, RA, RB, RR, RRA, RRB, RRR}
+
+// ExecuteOperation executes a single operation on the given stacks.
+// Swaps and rotations on a stack with fewer than 2 elements do nothing.
 func ExecuteOperation(stackA, stackB *stack.Stack, op Operation) error {
+	_, err := Execute(stackA, stackB, op)
+	return err
+}
+
+// Execute executes a single operation like ExecuteOperation and returns a
+// Record of which stacks it actually changed, so that it can be undone
+func Execute(stackA, stackB *stack.Stack, op Operation) (Record, error) {
+	rec := Record{Op: op}
+	
 	switch op {
 	case SA:
-		return swapA(stackA)
+		rec.ChangedA = stackA.Swap()
 	case SB:
-		return swapB(stackB)
+		rec.ChangedB = stackB.Swap()
 	case SS:
-		swapA(stackA) // Ignore errors for ss operation
-		return swapB(stackB)
+		rec.ChangedA = stackA.Swap()
+		rec.ChangedB = stackB.Swap()
 	case PA:
-		return pushA(stackA, stackB)
+		if err := push(stackB, stackA, "b"); err != nil {
+			return rec, err
+		}
+		rec.ChangedA, rec.ChangedB = true, true
 	case PB:
-		return pushB(stackA, stackB)
+		if err := push(stackA, stackB, "a"); err != nil {
+			return rec, err
+		}
+		rec.ChangedA, rec.ChangedB = true, true
 	case RA:
-		return rotateA(stackA)
+		rec.ChangedA = stackA.Rotate()
 	case RB:
-		return rotateB(stackB)
+		rec.ChangedB = stackB.Rotate()
 	case RR:
-		rotateA(stackA) // Ignore errors for rr operation
-		return rotateB(stackB)
+		rec.ChangedA = stackA.Rotate()
+		rec.ChangedB = stackB.Rotate()
 	case RRA:
-		return reverseRotateA(stackA)
+		rec.ChangedA = stackA.ReverseRotate()
 	case RRB:
-		return reverseRotateB(stackB)
+		rec.ChangedB = stackB.ReverseRotate()
 	case RRR:
-		reverseRotateA(stackA) // Ignore errors for rrr operation
-		return reverseRotateB(stackB)
+		rec.ChangedA = stackA.ReverseRotate()
+		rec.ChangedB = stackB.ReverseRotate()
 	default:
-		return fmt.Errorf("unknown operation: %s", op)
+		return rec, fmt.Errorf("unknown operation: %s", op)
 	}
+	return rec, nil
 }
 
 // ExecuteOperations executes a sequence of operations
@@ -80,138 +101,13 @@ func ExecuteOperations(stackA, stackB *stack.Stack, operations []Operation) erro
 	return nil
 }
 
-// swapA swaps the first 2 elements of stack a
-func swapA(stackA *stack.Stack) error {
-	if stackA.Size() < 2 {
-		return nil // No error, just no operation needed
-	}
-	
-	first, _ := stackA.Pop()
-	second, _ := stackA.Pop()
-	
-	stackA.Push(first)
-	stackA.Push(second)
-	
-	return nil
-}
-
-// swapB swaps the first 2 elements of stack b
-func swapB(stackB *stack.Stack) error {
-	if stackB.Size() < 2 {
-		return nil // No error, just no operation needed
-	}
-	
-	first, _ := stackB.Pop()
-	second, _ := stackB.Pop()
-	
-	stackB.Push(first)
-	stackB.Push(second)
-	
-	return nil
-}
-
-// pushA pushes the top element of stack b to stack a
-func pushA(stackA, stackB *stack.Stack) error {
-	if stackB.IsEmpty() {
-		return fmt.Errorf("cannot push from empty stack b")
-	}
-	
-	value, err := stackB.Pop()
+// push moves the top element of stack from onto stack to
+func push(from, to *stack.Stack, fromName string) error {
+	value, err := from.Pop()
 	if err != nil {
-		return err
-	}
-	
-	stackA.Push(value)
-	return nil
-}
-
-// pushB pushes the top element of stack a to stack b
-func pushB(stackA, stackB *stack.Stack) error {
-	if stackA.IsEmpty() {
-		return fmt.Errorf("cannot push from empty stack a")
-	}
-	
-	value, err := stackA.Pop()
-	if err != nil {
-		return err
-	}
-	
-	stackB.Push(value)
-	return nil
-}
-
-// rotateA rotates stack a (shift up all elements by 1)
-func rotateA(stackA *stack.Stack) error {
-	if stackA.Size() < 2 {
-		return nil // No operation needed
-	}
-	
-	data := stackA.ToSlice()
-	first := data[0]
-	
-	// Shift all elements up
-	for i := 0; i < len(data)-1; i++ {
-		data[i] = data[i+1]
-	}
-	data[len(data)-1] = first
-	
-	*stackA = *stack.NewStack(data)
-	return nil
-}
-
-// rotateB rotates stack b
-func rotateB(stackB *stack.Stack) error {
-	if stackB.Size() < 2 {
-		return nil // No operation needed
-	}
-	
-	data := stackB.ToSlice()
-	first := data[0]
-	
-	// Shift all elements up
-	for i := 0; i < len(data)-1; i++ {
-		data[i] = data[i+1]
-	}
-	data[len(data)-1] = first
-	
-	*stackB = *stack.NewStack(data)
-	return nil
-}
-
-// reverseRotateA reverse rotates stack a (shift down all elements by 1)
-func reverseRotateA(stackA *stack.Stack) error {
-	if stackA.Size() < 2 {
-		return nil // No operation needed
-	}
-	
-	data := stackA.ToSlice()
-	last := data[len(data)-1]
-	
-	// Shift all elements down
-	for i := len(data) - 1; i > 0; i-- {
-		data[i] = data[i-1]
-	}
-	data[0] = last
-	
-	*stackA = *stack.NewStack(data)
-	return nil
-}
-
-// reverseRotateB reverse rotates stack b
-func reverseRotateB(stackB *stack.Stack) error {
-	if stackB.Size() < 2 {
-		return nil // No operation needed
-	}
-	
-	data := stackB.ToSlice()
-	last := data[len(data)-1]
-	
-	// Shift all elements down
-	for i := len(data) - 1; i > 0; i-- {
-		data[i] = data[i-1]
+		return fmt.Errorf("cannot push from empty stack %s", fromName)
 	}
-	data[0] = last
 	
-	*stackB = *stack.NewStack(data)
+	to.Push(value)
 	return nil
 }
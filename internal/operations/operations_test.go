@@ -178,7 +178,8 @@ func TestExecuteOperations(t *testing.T) {
 	stackA := stack.NewStack([]int{3, 2, 1})
 	stackB := stack.NewEmptyStack()
 	
-	ops := []Operation{SA, PB, PB, SA, PA, PA}
+	// [3 2 1] -pb-> [2 1] | [3] -sa-> [1 2] | [3] -pa-> [3 1 2] -ra-> [1 2 3]
+	ops := []Operation{PB, SA, PA, RA}
 	
 	err := ExecuteOperations(stackA, stackB, ops)
 	if err != nil {
@@ -203,4 +204,43 @@ func TestInvalidOperation(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error for invalid operation")
 	}
-}
\ No newline at end of file
+}
+
+func TestRotateAndReverseRotateBoth(t *testing.T) {
+	stackA := stack.NewStack([]int{1, 2, 3})
+	stackB := stack.NewStack([]int{4, 5, 6})
+	
+	if err := ExecuteOperation(stackA, stackB, RR); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if stackA.String() != "[2 3 1]" || stackB.String() != "[5 6 4]" {
+		t.Errorf("Expected [2 3 1] and [5 6 4] after RR, got %s and %s", stackA, stackB)
+	}
+	
+	if err := ExecuteOperation(stackA, stackB, RRR); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if stackA.String() != "[1 2 3]" || stackB.String() != "[4 5 6]" {
+		t.Errorf("Expected [1 2 3] and [4 5 6] after RRR, got %s and %s", stackA, stackB)
+	}
+}
+
+func TestCombinedOperationsWithSmallStack(t *testing.T) {
+	// The half acting on a stack with fewer than 2 elements does nothing
+	stackA := stack.NewStack([]int{1})
+	stackB := stack.NewStack([]int{2, 3})
+	
+	for _, op := range []Operation{SS, RR, RRR} {
+		if err := ExecuteOperation(stackA, stackB, op); err != nil {
+			t.Errorf("Unexpected error for %s: %v", op, err)
+		}
+	}
+	
+	if stackA.String() != "[1]" {
+		t.Errorf("Expected stack A to stay [1], got %s", stackA)
+	}
+	// ss, rr and rrr each change B: [2 3] -> [3 2] -> [2 3] -> [3 2]
+	if stackB.String() != "[3 2]" {
+		t.Errorf("Expected stack B to be [3 2], got %s", stackB)
+	}
+}
@@ -0,0 +1,66 @@
+package operations
+
+import (
+	"errors"
+	"fmt"
+	"push-swap/internal/stack"
+)
+
+// ErrNoOp is returned in strict mode for an operation that leaves a stack
+// it acts on unchanged, such as sa on a stack with one element
+var ErrNoOp = errors.New("operation does not change the stacks")
+
+// OpError reports the operation of a program that failed to execute
+type OpError struct {
+	Index int // 1-based position of the operation in the program
+	Op    Operation
+	Err   error
+}
+
+func (e *OpError) Error() string {
+	return fmt.Sprintf("operation %d (%s): %v", e.Index, e.Op, e.Err)
+}
+
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
+// ExecuteStrict executes a single operation like ExecuteOperation, but
+// returns an error wrapping ErrNoOp if the operation is wasted. The
+// combined operations ss, rr and rrr must change both stacks; when only
+// one half takes effect it is undone, so a rejected operation always
+// leaves both stacks as they were.
+func ExecuteStrict(stackA, stackB *stack.Stack, op Operation) error {
+	rec, err := Execute(stackA, stackB, op)
+	if err != nil {
+		return err
+	}
+
+	_, combined := halves[op]
+	var noOp error
+	switch {
+	case combined && !rec.ChangedA:
+		noOp = fmt.Errorf("%w: stack a is unchanged", ErrNoOp)
+	case combined && !rec.ChangedB:
+		noOp = fmt.Errorf("%w: stack b is unchanged", ErrNoOp)
+	case !rec.Changed():
+		noOp = ErrNoOp
+	}
+	if noOp != nil {
+		if err := Undo(stackA, stackB, rec); err != nil {
+			return err
+		}
+	}
+	return noOp
+}
+
+// ExecuteOperationsStrict executes a sequence of operations in strict mode.
+// The first failing operation is reported as an *OpError.
+func ExecuteOperationsStrict(stackA, stackB *stack.Stack, operations []Operation) error {
+	for i, op := range operations {
+		if err := ExecuteStrict(stackA, stackB, op); err != nil {
+			return &OpError{Index: i + 1, Op: op, Err: err}
+		}
+	}
+	return nil
+}
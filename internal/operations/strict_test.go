@@ -0,0 +1,87 @@
+package operations
+
+import (
+	"errors"
+	"push-swap/internal/stack"
+	"testing"
+)
+
+func TestExecuteStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    []int
+		op      Operation
+		wantErr bool
+	}{
+		{"sa on two elements", []int{1, 2}, nil, SA, false},
+		{"sa on one element", []int{1}, nil, SA, true},
+		{"rb on empty stack", []int{1, 2}, nil, RB, true},
+		{"rra on one element", []int{1}, []int{2, 3}, RRA, true},
+		{"ss on both", []int{1, 2}, []int{3, 4}, SS, false},
+		{"ss with small a", []int{1}, []int{2, 3}, SS, true},
+		{"rr with small b", []int{1, 2}, []int{3}, RR, true},
+		{"rr with small b rotates nothing", []int{2, 1, 3}, nil, RR, true},
+		{"ss with small a swaps nothing", []int{1}, []int{3, 2}, SS, true},
+		{"rrr with both small", []int{1}, []int{2}, RRR, true},
+		{"pb", []int{1}, nil, PB, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stackA, stackB := stack.NewStack(tt.a), stack.NewStack(tt.b)
+			err := ExecuteStrict(stackA, stackB, tt.op)
+			if tt.wantErr {
+				if !errors.Is(err, ErrNoOp) {
+					t.Errorf("Expected ErrNoOp, got %v", err)
+				}
+				// A rejected operation must not change either stack
+				want := stack.NewStack(tt.a).String() + " " + stack.NewStack(tt.b).String()
+				if got := stackA.String() + " " + stackB.String(); got != want {
+					t.Errorf("Expected stacks %s to be left alone, got %s", want, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestExecuteStrictPushFromEmpty(t *testing.T) {
+	err := ExecuteStrict(stack.NewStack([]int{1}), stack.NewEmptyStack(), PA)
+	if err == nil || errors.Is(err, ErrNoOp) {
+		t.Errorf("Expected a push error rather than ErrNoOp, got %v", err)
+	}
+}
+
+func TestExecuteOperationsStrict(t *testing.T) {
+	stackA := stack.NewStack([]int{2, 1, 3})
+	stackB := stack.NewEmptyStack()
+
+	// The second sb acts on a stack b with a single element
+	err := ExecuteOperationsStrict(stackA, stackB, []Operation{SA, PB, SB, PA})
+
+	var opErr *OpError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("Expected *OpError, got %v", err)
+	}
+	if opErr.Index != 3 || opErr.Op != SB {
+		t.Errorf("Expected failure at operation 3 (sb), got %d (%s)", opErr.Index, opErr.Op)
+	}
+	if !errors.Is(err, ErrNoOp) {
+		t.Errorf("Expected error to wrap ErrNoOp, got %v", err)
+	}
+}
+
+func TestExecuteOperationsStrictValidProgram(t *testing.T) {
+	stackA := stack.NewStack([]int{3, 2, 1})
+	stackB := stack.NewEmptyStack()
+
+	if err := ExecuteOperationsStrict(stackA, stackB, []Operation{SA, RRA}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !stackA.IsSorted() {
+		t.Errorf("Expected sorted stack, got %s", stackA)
+	}
+}
@@ -0,0 +1,92 @@
+package operations
+
+import (
+	"fmt"
+	"push-swap/internal/stack"
+)
+
+// Record describes an executed operation and which stacks it changed.
+// Swaps and rotations leave a stack with fewer than 2 elements untouched,
+// and a failed push changes nothing.
+type Record struct {
+	Op       Operation
+	ChangedA bool
+	ChangedB bool
+}
+
+// Changed reports whether the operation changed either stack
+func (r Record) Changed() bool {
+	return r.ChangedA || r.ChangedB
+}
+
+// inverses maps every operation to the operation that undoes it
+var inverses = map[Operation]Operation{
+	SA:  SA,
+	SB:  SB,
+	SS:  SS,
+	PA:  PB,
+	PB:  PA,
+	RA:  RRA,
+	RB:  RRB,
+	RR:  RRR,
+	RRA: RA,
+	RRB: RB,
+	RRR: RR,
+}
+
+// halves maps combined operations to their stack a and stack b parts
+var halves = map[Operation][2]Operation{
+	SS:  {SA, SB},
+	RR:  {RA, RB},
+	RRR: {RRA, RRB},
+}
+
+// Inverse returns the operation that undoes op. The second result is
+// false if op is not a valid operation.
+func Inverse(op Operation) (Operation, bool) {
+	inverse, ok := inverses[op]
+	return inverse, ok
+}
+
+// ExecuteInverse executes the inverse of op on the given stacks
+func ExecuteInverse(stackA, stackB *stack.Stack, op Operation) error {
+	inverse, ok := Inverse(op)
+	if !ok {
+		return fmt.Errorf("unknown operation: %s", op)
+	}
+	return ExecuteOperation(stackA, stackB, inverse)
+}
+
+// Undo reverts the effect of a recorded operation. Only the stacks the
+// operation changed are touched, so a no-op is undone as a no-op.
+func Undo(stackA, stackB *stack.Stack, rec Record) error {
+	if !rec.Changed() {
+		return nil
+	}
+
+	inverse, ok := Inverse(rec.Op)
+	if !ok {
+		return fmt.Errorf("unknown operation: %s", rec.Op)
+	}
+
+	// Only part of a combined operation took effect
+	if parts, combined := halves[inverse]; combined && rec.ChangedA != rec.ChangedB {
+		if rec.ChangedA {
+			inverse = parts[0]
+		} else {
+			inverse = parts[1]
+		}
+	}
+
+	return ExecuteOperation(stackA, stackB, inverse)
+}
+
+// UndoAll reverts a sequence of recorded operations, last one first
+func UndoAll(stackA, stackB *stack.Stack, records []Record) error {
+	for i := len(records) - 1; i >= 0; i-- {
+		if err := Undo(stackA, stackB, records[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package operations
+
+import (
+	"math/rand"
+	"push-swap/internal/stack"
+	"reflect"
+	"testing"
+)
+
+func TestInverse(t *testing.T) {
+	expected := map[Operation]Operation{
+		SA: SA, SB: SB, SS: SS,
+		PA: PB, PB: PA,
+		RA: RRA, RB: RRB, RR: RRR,
+		RRA: RA, RRB: RB, RRR: RR,
+	}
+
+	for _, op := range AllOperations {
+		inverse, ok := Inverse(op)
+		if !ok || inverse != expected[op] {
+			t.Errorf("Expected inverse of %s to be %s, got %s", op, expected[op], inverse)
+		}
+
+		// Inverting twice gives back the original operation
+		if back, _ := Inverse(inverse); back != op {
+			t.Errorf("Expected inverse of %s to be %s, got %s", inverse, op, back)
+		}
+	}
+
+	if _, ok := Inverse(Operation("invalid")); ok {
+		t.Error("Expected no inverse for invalid operation")
+	}
+}
+
+func TestExecuteRecordsChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     []int
+		op       Operation
+		changedA bool
+		changedB bool
+	}{
+		{"sa on two elements", []int{1, 2}, nil, SA, true, false},
+		{"sa on one element", []int{1}, nil, SA, false, false},
+		{"ss with small a", []int{1}, []int{2, 3}, SS, false, true},
+		{"rr with small b", []int{1, 2}, []int{3}, RR, true, false},
+		{"rrr on both", []int{1, 2}, []int{3, 4}, RRR, true, true},
+		{"pb", []int{1}, nil, PB, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, err := Execute(stack.NewStack(tt.a), stack.NewStack(tt.b), tt.op)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if rec.ChangedA != tt.changedA || rec.ChangedB != tt.changedB {
+				t.Errorf("Expected changes a=%v b=%v, got a=%v b=%v",
+					tt.changedA, tt.changedB, rec.ChangedA, rec.ChangedB)
+			}
+		})
+	}
+}
+
+func TestExecuteFailedPushChangesNothing(t *testing.T) {
+	rec, err := Execute(stack.NewStack([]int{1}), stack.NewEmptyStack(), PA)
+	if err == nil {
+		t.Fatal("Expected error when pushing from empty stack B")
+	}
+	if rec.Changed() {
+		t.Error("A failed push should not be recorded as a change")
+	}
+}
+
+func TestUndoPartialCombinedOperation(t *testing.T) {
+	stackA := stack.NewStack([]int{1})
+	stackB := stack.NewStack([]int{2, 3, 4})
+
+	rec, _ := Execute(stackA, stackB, RR)
+	if err := Undo(stackA, stackB, rec); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if stackA.String() != "[1]" || stackB.String() != "[2 3 4]" {
+		t.Errorf("Expected [1] and [2 3 4] after undo, got %s and %s", stackA, stackB)
+	}
+}
+
+func TestExecuteInverse(t *testing.T) {
+	stackA := stack.NewStack([]int{1, 2, 3})
+	stackB := stack.NewEmptyStack()
+
+	ExecuteOperation(stackA, stackB, RA)
+	if err := ExecuteInverse(stackA, stackB, RA); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stackA.String() != "[1 2 3]" {
+		t.Errorf("Expected [1 2 3], got %s", stackA)
+	}
+
+	if err := ExecuteInverse(stackA, stackB, Operation("invalid")); err == nil {
+		t.Error("Expected error for invalid operation")
+	}
+}
+
+func TestUndoAllRestoresInitialState(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+
+	for trial := 0; trial < 500; trial++ {
+		input := rng.Perm(rng.Intn(6))
+		stackA := stack.NewStack(input)
+		stackB := stack.NewEmptyStack()
+
+		// Random programs include no-ops and failing pushes, which are
+		// recorded but leave the stacks untouched
+		var records []Record
+		for i := 0; i < 30; i++ {
+			op := AllOperations[rng.Intn(len(AllOperations))]
+			rec, _ := Execute(stackA, stackB, op)
+			records = append(records, rec)
+		}
+
+		if err := UndoAll(stackA, stackB, records); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(stackA.ToSlice(), append([]int{}, input...)) || !stackB.IsEmpty() {
+			t.Fatalf("Expected A=%v and empty B after undo, got A=%s B=%s", input, stackA, stackB)
+		}
+	}
+}
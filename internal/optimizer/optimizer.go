@@ -0,0 +1,156 @@
+package optimizer
+
+import (
+	"push-swap/internal/operations"
+)
+
+// opPair is two operations executed one after the other
+type opPair struct {
+	first, second operations.Operation
+}
+
+// rewrites maps a pair of operations to an equivalent shorter program.
+// An empty replacement means the pair cancels out.
+var rewrites = map[opPair][]operations.Operation{
+	// Inverse pairs
+	{operations.SA, operations.SA}:  {},
+	{operations.SB, operations.SB}:  {},
+	{operations.SS, operations.SS}:  {},
+	{operations.PA, operations.PB}:  {},
+	{operations.PB, operations.PA}:  {},
+	{operations.RA, operations.RRA}: {},
+	{operations.RB, operations.RRB}: {},
+	{operations.RR, operations.RRR}: {},
+	{operations.RRA, operations.RA}: {},
+	{operations.RRB, operations.RB}: {},
+	{operations.RRR, operations.RR}: {},
+
+	// Merges into a combined operation
+	{operations.SA, operations.SB}:   {operations.SS},
+	{operations.SB, operations.SA}:   {operations.SS},
+	{operations.RA, operations.RB}:   {operations.RR},
+	{operations.RB, operations.RA}:   {operations.RR},
+	{operations.RRA, operations.RRB}: {operations.RRR},
+	{operations.RRB, operations.RRA}: {operations.RRR},
+
+	// A combined operation absorbing half of its inverse
+	{operations.SS, operations.SA}:  {operations.SB},
+	{operations.SA, operations.SS}:  {operations.SB},
+	{operations.SS, operations.SB}:  {operations.SA},
+	{operations.SB, operations.SS}:  {operations.SA},
+	{operations.RR, operations.RRA}: {operations.RB},
+	{operations.RRA, operations.RR}: {operations.RB},
+	{operations.RR, operations.RRB}: {operations.RA},
+	{operations.RRB, operations.RR}: {operations.RA},
+	{operations.RRR, operations.RA}: {operations.RRB},
+	{operations.RA, operations.RRR}: {operations.RRB},
+	{operations.RRR, operations.RB}: {operations.RRA},
+	{operations.RB, operations.RRR}: {operations.RRA},
+}
+
+// Optimize rewrites a program into an equivalent one that is no longer,
+// leaving both stacks in the same final state. It merges pairs such as
+// ra+rb into rr and removes inverse pairs such as pb+pa, including when
+// the two operations are separated by operations they commute with.
+func Optimize(ops []operations.Operation) []operations.Operation {
+	result := make([]operations.Operation, len(ops))
+	copy(result, ops)
+
+	// A merge can expose a new pair, so repeat until nothing changes
+	for {
+		next := optimizePass(result)
+		if len(next) == len(result) {
+			return next
+		}
+		result = next
+	}
+}
+
+// optimizePass rebuilds the program one operation at a time
+func optimizePass(ops []operations.Operation) []operations.Operation {
+	out := make([]operations.Operation, 0, len(ops))
+	for _, op := range ops {
+		out = appendOptimized(out, op)
+	}
+	return out
+}
+
+// appendOptimized appends op to out unless it can be moved back next to an
+// earlier operation and cancelled or merged with it. Cancelling is
+// preferred because it saves two operations instead of one.
+func appendOptimized(out []operations.Operation, op operations.Operation) []operations.Operation {
+	mergeAt := -1
+	var merged operations.Operation
+
+	for j := len(out) - 1; j >= 0; j-- {
+		if replacement, ok := rewrites[opPair{out[j], op}]; ok {
+			if len(replacement) == 0 {
+				return append(out[:j], out[j+1:]...)
+			}
+			if mergeAt < 0 {
+				mergeAt = j
+				merged = replacement[0]
+			}
+		}
+		if !Commute(out[j], op) {
+			break
+		}
+		// Anything further back that op could pair with, the identical
+		// op found here could pair with too; Optimize repeats passes until
+		// that happens. Stopping keeps long runs such as pa pa pa ... linear.
+		if out[j] == op {
+			break
+		}
+	}
+
+	if mergeAt >= 0 {
+		out[mergeAt] = merged
+		return out
+	}
+	return append(out, op)
+}
+
+// Commute reports whether executing x then y always has the same effect
+// as executing y then x
+func Commute(x, y operations.Operation) bool {
+	if x == y {
+		return true
+	}
+	// Rotations of a stack commute with each other, and so do swaps
+	if isRotation(x) && isRotation(y) {
+		return true
+	}
+	if isSwap(x) && isSwap(y) {
+		return true
+	}
+	return touchedStacks(x)&touchedStacks(y) == 0
+}
+
+const (
+	touchesA = 1 << iota
+	touchesB
+)
+
+// touchedStacks returns a bit set of the stacks an operation modifies
+func touchedStacks(op operations.Operation) int {
+	switch op {
+	case operations.SA, operations.RA, operations.RRA:
+		return touchesA
+	case operations.SB, operations.RB, operations.RRB:
+		return touchesB
+	default:
+		return touchesA | touchesB
+	}
+}
+
+func isRotation(op operations.Operation) bool {
+	switch op {
+	case operations.RA, operations.RB, operations.RR, operations.RRA, operations.RRB, operations.RRR:
+		return true
+	}
+	return false
+}
+
+func isSwap(op operations.Operation) bool {
+	return op == operations.SA || op == operations.SB || op == operations.SS
+}
@@ -0,0 +1,184 @@
+package optimizer
+
+import (
+	"math/rand"
+	"push-swap/internal/operations"
+	"push-swap/internal/stack"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// program builds an operation list from a space-separated string
+func program(s string) []operations.Operation {
+	ops := []operations.Operation{}
+	for _, field := range strings.Fields(s) {
+		ops = append(ops, operations.Operation(field))
+	}
+	return ops
+}
+
+func TestOptimize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Empty program", "", ""},
+		{"Nothing to do", "pb ra pa", "pb ra pa"},
+		{"Merge ra rb", "ra rb", "rr"},
+		{"Merge rra rrb", "rrb rra", "rrr"},
+		{"Merge sa sb", "sa sb", "ss"},
+		{"Cancel pb pa", "pb pa", ""},
+		{"Cancel pa pb", "pb pb pa pb", "pb pb"},
+		{"Cancel ra rra", "ra rra", ""},
+		{"Cancel sa sa", "sa sa", ""},
+		{"Cancel rr rrr", "rr rrr", ""},
+		{"Cancel across other stack", "ra rb rb rra", "rb rb"},
+		{"Cancel across other stack swaps", "sa rb sa", "rb"},
+		{"Merge across other stack", "ra sa rb", "rr sa"},
+		{"Rotations commute on same stack", "ra rb ra rra", "rr"},
+		{"Combined absorbs half", "rr rra", "rb"},
+		{"Chained cancellation", "pb ra rra pa", ""},
+		{"Push blocks cancellation", "ra pb rra", "ra pb rra"},
+		{"Swap blocks rotation", "ra sa rra", "ra sa rra"},
+		{"Prefer cancel over merge", "rra rb ra", "rb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Optimize(program(tt.input))
+			expected := program(tt.expected)
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("Expected %v, got %v", expected, result)
+			}
+		})
+	}
+}
+
+func TestOptimizeDoesNotModifyInput(t *testing.T) {
+	input := program("ra rb pb pa")
+	Optimize(input)
+
+	if !reflect.DeepEqual(input, program("ra rb pb pa")) {
+		t.Errorf("Optimize should not modify its input, got %v", input)
+	}
+}
+
+func TestCommute(t *testing.T) {
+	tests := []struct {
+		x, y     operations.Operation
+		expected bool
+	}{
+		{operations.RA, operations.RB, true},
+		{operations.RA, operations.RRA, true},
+		{operations.RR, operations.RRB, true},
+		{operations.SA, operations.SS, true},
+		{operations.SA, operations.RB, true},
+		{operations.SA, operations.RA, false},
+		{operations.PA, operations.RB, false},
+		{operations.PA, operations.PB, false},
+		{operations.SS, operations.RA, false},
+	}
+
+	for _, tt := range tests {
+		if got := Commute(tt.x, tt.y); got != tt.expected {
+			t.Errorf("Commute(%s, %s) = %v, expected %v", tt.x, tt.y, got, tt.expected)
+		}
+		if got := Commute(tt.y, tt.x); got != tt.expected {
+			t.Errorf("Commute(%s, %s) = %v, expected %v", tt.y, tt.x, got, tt.expected)
+		}
+	}
+}
+
+// randomProgram generates a program that executes without error on
+// stacks of the given sizes, biased towards pairs the optimizer rewrites:
+// half of the operations are picked among those that form such a pair
+// with the previous one
+func randomProgram(rng *rand.Rand, sizeA, length int) []operations.Operation {
+	sizeB := 0
+	ops := make([]operations.Operation, 0, length)
+	for len(ops) < length {
+		op := operations.AllOperations[rng.Intn(len(operations.AllOperations))]
+		if len(ops) > 0 && rng.Intn(2) == 0 {
+			if partners := rewritePartners(ops[len(ops)-1]); len(partners) > 0 {
+				op = partners[rng.Intn(len(partners))]
+			}
+		}
+		if op == operations.PA && sizeB == 0 || op == operations.PB && sizeA == 0 {
+			continue
+		}
+		switch op {
+		case operations.PA:
+			sizeA, sizeB = sizeA+1, sizeB-1
+		case operations.PB:
+			sizeA, sizeB = sizeA-1, sizeB+1
+		}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+// rewritePartners returns the operations that the optimizer rewrites when
+// they directly follow prev, in a fixed order
+func rewritePartners(prev operations.Operation) []operations.Operation {
+	var partners []operations.Operation
+	for _, op := range operations.AllOperations {
+		if _, ok := rewrites[opPair{prev, op}]; ok {
+			partners = append(partners, op)
+		}
+	}
+	return partners
+}
+
+func TestOptimizePreservesFinalState(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for trial := 0; trial < 2000; trial++ {
+		size := 1 + rng.Intn(8)
+		input := rng.Perm(size)
+		ops := randomProgram(rng, size, rng.Intn(40))
+
+		optimized := Optimize(ops)
+		if len(optimized) > len(ops) {
+			t.Fatalf("Optimized program %v is longer than %v", optimized, ops)
+		}
+
+		wantA, wantB := stack.NewStack(input), stack.NewEmptyStack()
+		if err := operations.ExecuteOperations(wantA, wantB, ops); err != nil {
+			t.Fatalf("Generated program %v is invalid: %v", ops, err)
+		}
+
+		gotA, gotB := stack.NewStack(input), stack.NewEmptyStack()
+		if err := operations.ExecuteOperations(gotA, gotB, optimized); err != nil {
+			t.Fatalf("Optimized program %v failed: %v", optimized, err)
+		}
+
+		if !reflect.DeepEqual(wantA.ToSlice(), gotA.ToSlice()) || !reflect.DeepEqual(wantB.ToSlice(), gotB.ToSlice()) {
+			t.Fatalf("Program %v on %v: expected A=%v B=%v, optimized %v gives A=%v B=%v",
+				ops, input, wantA, wantB, optimized, gotA, gotB)
+		}
+	}
+}
+
+func TestOptimizeLongRunIsLinear(t *testing.T) {
+	// Every ra commutes with the others, so without stopping at an
+	// identical operation each one scans the whole run before it
+	ops := make([]operations.Operation, 100000)
+	for i := range ops {
+		ops[i] = operations.RA
+	}
+
+	done := make(chan []operations.Operation, 1)
+	go func() { done <- Optimize(ops) }()
+
+	select {
+	case optimized := <-done:
+		if len(optimized) != len(ops) {
+			t.Errorf("Expected %d ops, got %d", len(ops), len(optimized))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected a run of 100000 ra to be optimized in linear time, still running after 5s")
+	}
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+)
+
+// Domain is the range of integers accepted as input
+type Domain int
+
+const (
+	// Int32 is the range required by the push-swap specification
+	Int32 Domain = iota
+	// Int64 accepts any value that fits in a 64-bit integer
+	Int64
+)
+
+// DefaultDomain is the domain used by ParseArguments
+const DefaultDomain = Int32
+
+// Bounds returns the smallest and largest values in the domain
+func (d Domain) Bounds() (int64, int64) {
+	if d == Int64 {
+		return math.MinInt64, math.MaxInt64
+	}
+	return math.MinInt32, math.MaxInt32
+}
+
+// Contains reports whether n lies within the domain
+func (d Domain) Contains(n int64) bool {
+	lo, hi := d.Bounds()
+	return n >= lo && n <= hi
+}
+
+// String returns the name of the domain as accepted by Set
+func (d Domain) String() string {
+	if d == Int64 {
+		return "int64"
+	}
+	return "int32"
+}
+
+// Set selects a domain by name, so a Domain can be used as a flag.Value
+func (d *Domain) Set(name string) error {
+	switch name {
+	case "int32":
+		*d = Int32
+	case "int64":
+		*d = Int64
+	default:
+		return fmt.Errorf("unknown integer domain %q (want int32 or int64)", name)
+	}
+	return nil
+}
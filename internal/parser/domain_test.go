@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"errors"
+	"flag"
+	"reflect"
+	"testing"
+)
+
+func TestParseArgumentsDomainBoundaries(t *testing.T) {
+	tests := []struct {
+		name       string
+		arg        string
+		domain     Domain
+		expected   []int
+		outOfRange bool
+	}{
+		{"Int32 max", "2147483647", Int32, []int{2147483647}, false},
+		{"Int32 min", "-2147483648", Int32, []int{-2147483648}, false},
+		{"Int32 max plus one", "2147483648", Int32, nil, true},
+		{"Int32 min minus one", "-2147483649", Int32, nil, true},
+		{"Int32 far out of range", "9999999999", Int32, nil, true},
+		{"Int32 explicit plus sign", "+2147483647", Int32, []int{2147483647}, false},
+		{"Int64 accepts past int32", "2147483648", Int64, []int{2147483648}, false},
+		{"Int64 accepts below int32", "-2147483649", Int64, []int{-2147483649}, false},
+		{"Int64 max", "9223372036854775807", Int64, []int{9223372036854775807}, false},
+		{"Int64 max plus one", "9223372036854775808", Int64, nil, true},
+		{"Int64 min minus one", "-9223372036854775809", Int64, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseArgumentsIn([]string{tt.arg}, tt.domain)
+
+			if tt.outOfRange {
+				var rangeErr *OutOfRangeError
+				if !errors.As(err, &rangeErr) {
+					t.Fatalf("Expected *OutOfRangeError, got %v", err)
+				}
+				if rangeErr.Token != tt.arg || rangeErr.Domain != tt.domain {
+					t.Errorf("Expected token %s in %s, got %s in %s", tt.arg, tt.domain, rangeErr.Token, rangeErr.Domain)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseArgumentsDefaultDomain(t *testing.T) {
+	_, err := ParseArguments([]string{"1 2147483648"})
+
+	var rangeErr *OutOfRangeError
+	if !errors.As(err, &rangeErr) {
+		t.Fatalf("Expected *OutOfRangeError, got %v", err)
+	}
+	if rangeErr.Arg != 1 || rangeErr.Column != 3 {
+		t.Errorf("Expected argument 1, column 3, got argument %d, column %d", rangeErr.Arg, rangeErr.Column)
+	}
+}
+
+func TestDomainFlag(t *testing.T) {
+	var domain Domain
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&domain, "domain", "")
+
+	if err := fs.Parse([]string{"-domain", "int64"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if domain != Int64 {
+		t.Errorf("Expected int64, got %s", domain)
+	}
+
+	if err := domain.Set("int128"); err == nil {
+		t.Error("Expected error for unknown domain but got none")
+	}
+}
@@ -0,0 +1,63 @@
+package parser
+
+import "fmt"
+
+// InvalidIntegerError reports an argument token that is not an integer
+type InvalidIntegerError struct {
+	Token  string // the token as written
+	Arg    int    // 1-based index of the command line argument
+	Column int    // 1-based column of the token within the argument
+}
+
+func (e *InvalidIntegerError) Error() string {
+	return fmt.Sprintf("invalid integer %q at argument %d, column %d", e.Token, e.Arg, e.Column)
+}
+
+// OutOfRangeError reports an integer that does not fit in the domain
+type OutOfRangeError struct {
+	Token  string // the number as written
+	Arg    int    // 1-based index of the command line argument
+	Column int    // 1-based column of the token within the argument
+	Domain Domain
+}
+
+func (e *OutOfRangeError) Error() string {
+	return fmt.Sprintf("integer %s out of %s range at argument %d, column %d", e.Token, e.Domain, e.Arg, e.Column)
+}
+
+// DuplicateError reports a number that appears more than once. The
+// position is that of the repeated occurrence; FirstArg and FirstColumn
+// locate the earlier one.
+type DuplicateError struct {
+	Value       int
+	Token       string // the repeated occurrence as written
+	Arg         int    // 1-based index of the command line argument
+	Column      int    // 1-based column of the token within the argument
+	FirstArg    int
+	FirstColumn int
+}
+
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("duplicate number %d at argument %d, column %d (first seen at argument %d, column %d)",
+		e.Value, e.Arg, e.Column, e.FirstArg, e.FirstColumn)
+}
+
+// InvalidOperationError reports an input line that is not an operation
+type InvalidOperationError struct {
+	Token string // the line with surrounding whitespace removed
+	Line  int    // 1-based line number, counting blank lines
+}
+
+func (e *InvalidOperationError) Error() string {
+	return fmt.Sprintf("invalid operation %q on line %d", e.Token, e.Line)
+}
+
+// InputFormatError reports a line that breaks the strict input format
+type InputFormatError struct {
+	Line   int    // 1-based line number
+	Reason string // what is wrong with the line
+}
+
+func (e *InputFormatError) Error() string {
+	return fmt.Sprintf("malformed input on line %d: %s", e.Line, e.Reason)
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestInvalidIntegerError(t *testing.T) {
+	_, err := ParseArguments([]string{"1", "2 3 4x"})
+
+	var intErr *InvalidIntegerError
+	if !errors.As(err, &intErr) {
+		t.Fatalf("Expected *InvalidIntegerError, got %v", err)
+	}
+	if intErr.Token != "4x" || intErr.Arg != 2 || intErr.Column != 5 {
+		t.Errorf("Expected 4x at argument 2, column 5, got %s at argument %d, column %d",
+			intErr.Token, intErr.Arg, intErr.Column)
+	}
+}
+
+func TestDuplicateError(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		arg, column int
+		firstArg    int
+		firstColumn int
+	}{
+		{"Same argument", []string{"5 1 5"}, 1, 5, 1, 1},
+		{"Separate arguments", []string{"5", "1", "5"}, 3, 1, 1, 1},
+		{"Different spelling", []string{"7 +7"}, 1, 3, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseArguments(tt.args)
+
+			var dupErr *DuplicateError
+			if !errors.As(err, &dupErr) {
+				t.Fatalf("Expected *DuplicateError, got %v", err)
+			}
+			if dupErr.Arg != tt.arg || dupErr.Column != tt.column {
+				t.Errorf("Expected argument %d, column %d, got argument %d, column %d",
+					tt.arg, tt.column, dupErr.Arg, dupErr.Column)
+			}
+			if dupErr.FirstArg != tt.firstArg || dupErr.FirstColumn != tt.firstColumn {
+				t.Errorf("Expected first at argument %d, column %d, got argument %d, column %d",
+					tt.firstArg, tt.firstColumn, dupErr.FirstArg, dupErr.FirstColumn)
+			}
+		})
+	}
+}
+
+func TestFindDuplicateError(t *testing.T) {
+	tokens := tokenize([]string{"1 2", "3  1"})
+	var dupErr *DuplicateError
+	if !errors.As(findDuplicate([]int{1, 2, 3, 1}, tokens), &dupErr) {
+		t.Fatal("Expected *DuplicateError")
+	}
+	if dupErr.Value != 1 || dupErr.Arg != 2 || dupErr.Column != 4 || dupErr.FirstArg != 1 || dupErr.FirstColumn != 1 {
+		t.Errorf("Expected 1 at argument 2, column 4 (first at argument 1, column 1), got %d at argument %d, column %d (first at argument %d, column %d)",
+			dupErr.Value, dupErr.Arg, dupErr.Column, dupErr.FirstArg, dupErr.FirstColumn)
+	}
+}
+
+func TestInvalidOperationError(t *testing.T) {
+	_, err := ParseOperations([]string{"sa", "  ", " ra ", "sx "})
+
+	// Wrapped errors are still recognised
+	err = fmt.Errorf("reading instructions: %w", err)
+
+	var opErr *InvalidOperationError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("Expected *InvalidOperationError, got %v", err)
+	}
+	if opErr.Token != "sx" || opErr.Line != 4 {
+		t.Errorf("Expected sx on line 4, got %s on line %d", opErr.Token, opErr.Line)
+	}
+}
+
+func TestParseErrorTypesAreDistinct(t *testing.T) {
+	_, err := ParseArguments([]string{"1 1"})
+
+	var intErr *InvalidIntegerError
+	var rangeErr *OutOfRangeError
+	if errors.As(err, &intErr) || errors.As(err, &rangeErr) {
+		t.Errorf("Duplicate should not match other error types, got %T", err)
+	}
+}
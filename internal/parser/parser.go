@@ -1,76 +1,122 @@
 package parser
 
 import (
-	"fmt"
+	"errors"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
-// ParseArguments parses command line arguments into integers
+// token is a number as written on the command line
+type token struct {
+	text   string
+	arg    int // 1-based index of the command line argument
+	column int // 1-based column of the token within the argument
+}
+
+// ParseArguments parses command line arguments into integers within the
+// DefaultDomain
 func ParseArguments(args []string) ([]int, error) {
+	return ParseArgumentsIn(args, DefaultDomain)
+}
+
+// ParseArgumentsIn parses command line arguments into integers, rejecting
+// values outside domain. Errors are an *InvalidIntegerError,
+// *OutOfRangeError or *DuplicateError.
+func ParseArgumentsIn(args []string, domain Domain) ([]int, error) {
 	if len(args) == 0 {
 		return []int{}, nil
 	}
-	
+
 	var numbers []int
-	var allArgs []string
-	
+
 	// Handle the case where all numbers are in a single string
-	for _, arg := range args {
-		// Split by spaces to handle "2 1 3 6 5 8" format
-		parts := strings.Fields(arg)
-		allArgs = append(allArgs, parts...)
-	}
-	
+	// such as "2 1 3 6 5 8"
+	tokens := tokenize(args)
+
 	// Convert strings to integers
-	for _, str := range allArgs {
-		num, err := strconv.Atoi(str)
+	for _, tok := range tokens {
+		num, err := strconv.ParseInt(tok.text, 10, 64)
+		if errors.Is(err, strconv.ErrRange) || err == nil && !domain.Contains(num) {
+			return nil, &OutOfRangeError{Token: tok.text, Arg: tok.arg, Column: tok.column, Domain: domain}
+		}
 		if err != nil {
-			return nil, fmt.Errorf("invalid integer: %s", str)
+			return nil, &InvalidIntegerError{Token: tok.text, Arg: tok.arg, Column: tok.column}
 		}
-		numbers = append(numbers, num)
+		numbers = append(numbers, int(num))
 	}
-	
+
 	// Check for duplicates
-	if err := checkDuplicates(numbers); err != nil {
+	if err := findDuplicate(numbers, tokens); err != nil {
 		return nil, err
 	}
-	
+
 	return numbers, nil
 }
 
-// checkDuplicates checks if there are duplicate numbers in the slice
-func checkDuplicates(numbers []int) error {
-	seen := make(map[int]bool)
-	for _, num := range numbers {
-		if seen[num] {
-			return fmt.Errorf("duplicate number found: %d", num)
+// tokenize splits every argument on whitespace, remembering where each
+// token came from
+func tokenize(args []string) []token {
+	var tokens []token
+	for i, arg := range args {
+		start := -1
+		for j, r := range arg + " " {
+			isSpace := unicode.IsSpace(r)
+			switch {
+			case !isSpace && start < 0:
+				start = j
+			case isSpace && start >= 0:
+				tokens = append(tokens, token{text: arg[start:j], arg: i + 1, column: start + 1})
+				start = -1
+			}
+		}
+	}
+	return tokens
+}
+
+// findDuplicate returns a *DuplicateError for the first number that
+// already appeared earlier, where tokens[i] is the source of numbers[i]
+func findDuplicate(numbers []int, tokens []token) error {
+	seen := make(map[int]int)
+	for i, num := range numbers {
+		if first, ok := seen[num]; ok {
+			return &DuplicateError{
+				Value:       num,
+				Token:       tokens[i].text,
+				Arg:         tokens[i].arg,
+				Column:      tokens[i].column,
+				FirstArg:    tokens[first].arg,
+				FirstColumn: tokens[first].column,
+			}
 		}
-		seen[num] = true
+		seen[num] = i
 	}
 	return nil
 }
 
-// ParseOperations parses operation strings into Operation types
+// validOps is the set of instruction names
+var validOps = map[string]bool{
+	"sa": true, "sb": true, "ss": true,
+	"pa": true, "pb": true,
+	"ra": true, "rb": true, "rr": true,
+	"rra": true, "rrb": true, "rrr": true,
+}
+
+// ParseOperations parses operation strings into Operation types. Each
+// string is one input line; blank lines are skipped but still counted
+// in the Line of an *InvalidOperationError.
 func ParseOperations(operationStrings []string) ([]string, error) {
-	validOps := map[string]bool{
-		"sa": true, "sb": true, "ss": true,
-		"pa": true, "pb": true,
-		"ra": true, "rb": true, "rr": true,
-		"rra": true, "rrb": true, "rrr": true,
-	}
-	
-	var operations []string
-	for _, opStr := range operationStrings {
+	operations := []string{}
+	for i, opStr := range operationStrings {
 		opStr = strings.TrimSpace(opStr)
 		if opStr == "" {
 			continue
 		}
 		if !validOps[opStr] {
-			return nil, fmt.Errorf("invalid operation: %s", opStr)
+			return nil, &InvalidOperationError{Token: opStr, Line: i + 1}
 		}
 		operations = append(operations, opStr)
 	}
-	
+
 	return operations, nil
-}
\ No newline at end of file
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"reflect"
+	"strconv"
 	"testing"
 )
 
@@ -36,6 +37,12 @@ func TestParseArguments(t *testing.T) {
 			expected: []int{2, 1, 3, 6, 5, 8},
 			hasError: false,
 		},
+		{
+			name:     "Unicode whitespace separators",
+			args:     []string{"2\u00a01\u20033"},
+			expected: []int{2, 1, 3},
+			hasError: false,
+		},
 		{
 			name:     "Mixed format",
 			args:     []string{"1 2", "3", "4 5"},
@@ -131,7 +138,11 @@ func TestCheckDuplicates(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := checkDuplicates(tt.numbers)
+			args := make([]string, len(tt.numbers))
+			for i, num := range tt.numbers {
+				args[i] = strconv.Itoa(num)
+			}
+			_, err := ParseArguments(args)
 			
 			if tt.hasError && err == nil {
 				t.Error("Expected error but got none")
@@ -216,4 +227,39 @@ func TestParseOperations(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestParseErrorPositions(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"Invalid token in split argument", []string{"1 2 abc"}, `invalid integer "abc" at argument 1, column 5`},
+		{"Invalid separate argument", []string{"1", "2x"}, `invalid integer "2x" at argument 2, column 1`},
+		{"Duplicate points at second occurrence", []string{"3 1", "  3"}, "duplicate number 3 at argument 2, column 3 (first seen at argument 1, column 1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseArguments(tt.args)
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if err.Error() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestParseOperationsErrorLine(t *testing.T) {
+	_, err := ParseOperations([]string{"sa", "", "pb", "xx"})
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	expected := `invalid operation "xx" on line 4`
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+)
+
+// OperationScanner reads instructions one line at a time. In the default
+// mode it is as lenient as ParseOperations: surrounding whitespace is
+// trimmed and blank lines are skipped. In strict mode it matches the
+// reference checker, which requires exactly one instruction per line,
+// every line terminated by '\n', with no surrounding whitespace and no
+// blank lines.
+type OperationScanner struct {
+	scanner *bufio.Scanner
+	strict  bool
+	op      string
+	line    int
+	err     error
+}
+
+// NewOperationScanner returns a scanner reading instructions from r
+func NewOperationScanner(r io.Reader, strict bool) *OperationScanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(scanRawLines)
+	return &OperationScanner{scanner: scanner, strict: strict}
+}
+
+// Scan advances to the next instruction. It returns false at the end of
+// the input or at the first invalid line, after which Err reports the
+// problem.
+func (s *OperationScanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	for s.scanner.Scan() {
+		s.line++
+		raw := s.scanner.Text()
+		text, terminated := strings.CutSuffix(raw, "\n")
+		op := strings.TrimSpace(text)
+
+		if s.strict {
+			switch {
+			case !terminated:
+				s.err = &InputFormatError{Line: s.line, Reason: "missing newline at end of input"}
+			case text == "":
+				s.err = &InputFormatError{Line: s.line, Reason: "blank line"}
+			case op != text:
+				s.err = &InputFormatError{Line: s.line, Reason: "whitespace around instruction"}
+			}
+			if s.err != nil {
+				return false
+			}
+		} else if op == "" {
+			continue
+		}
+
+		if !validOps[op] {
+			s.err = &InvalidOperationError{Token: op, Line: s.line}
+			return false
+		}
+		s.op = op
+		return true
+	}
+
+	s.err = s.scanner.Err()
+	return false
+}
+
+// Op returns the instruction found by the last call to Scan
+func (s *OperationScanner) Op() string {
+	return s.op
+}
+
+// Line returns the 1-based line number of the last line read
+func (s *OperationScanner) Line() int {
+	return s.line
+}
+
+// Err returns the first error met by Scan: an *InvalidOperationError, an
+// *InputFormatError in strict mode, or an error from the reader
+func (s *OperationScanner) Err() error {
+	return s.err
+}
+
+// scanRawLines is like bufio.ScanLines but keeps the '\n' terminator, so
+// a final line without one can be told apart, and does not drop '\r'
+func scanRawLines(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, data[:i+1], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// scanAll collects every instruction read from input
+func scanAll(input string, strict bool) ([]string, error) {
+	scanner := NewOperationScanner(strings.NewReader(input), strict)
+	ops := []string{}
+	for scanner.Scan() {
+		ops = append(ops, scanner.Op())
+	}
+	return ops, scanner.Err()
+}
+
+func TestOperationScannerLenient(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"Empty input", "", []string{}},
+		{"Terminated lines", "sa\npb\n", []string{"sa", "pb"}},
+		{"Surrounding spaces and blank lines", " ra \n\nsa", []string{"ra", "sa"}},
+		{"Windows line endings", "sa\r\nrra\r\n", []string{"sa", "rra"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := scanAll(tt.input, false)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(ops, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, ops)
+			}
+		})
+	}
+}
+
+func TestOperationScannerStrict(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		line     int    // line of the expected error, 0 if none
+		reason   string // reason of the expected *InputFormatError
+	}{
+		{"Empty input", "", []string{}, 0, ""},
+		{"Terminated lines", "sa\npb\n", []string{"sa", "pb"}, 0, ""},
+		{"Surrounding spaces", " ra \nsa\n", []string{}, 1, "whitespace around instruction"},
+		{"Blank line", "ra\n\nsa\n", []string{"ra"}, 2, "blank line"},
+		{"Trailing partial line", "ra\nsa", []string{"ra"}, 2, "missing newline at end of input"},
+		{"Carriage return", "sa\r\n", []string{}, 1, "whitespace around instruction"},
+		{"Trailing tab", "sa\t\n", []string{}, 1, "whitespace around instruction"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := scanAll(tt.input, true)
+
+			if !reflect.DeepEqual(ops, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, ops)
+			}
+			if tt.line == 0 {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+
+			var formatErr *InputFormatError
+			if !errors.As(err, &formatErr) {
+				t.Fatalf("Expected *InputFormatError, got %v", err)
+			}
+			if formatErr.Line != tt.line || formatErr.Reason != tt.reason {
+				t.Errorf("Expected %q on line %d, got %q on line %d", tt.reason, tt.line, formatErr.Reason, formatErr.Line)
+			}
+		})
+	}
+}
+
+func TestOperationScannerInvalidOperation(t *testing.T) {
+	for _, strict := range []bool{false, true} {
+		ops, err := scanAll("sa\nrb\nrrx\nra\n", strict)
+
+		if !reflect.DeepEqual(ops, []string{"sa", "rb"}) {
+			t.Errorf("Expected operations before the error, got %v", ops)
+		}
+		var opErr *InvalidOperationError
+		if !errors.As(err, &opErr) {
+			t.Fatalf("Expected *InvalidOperationError, got %v", err)
+		}
+		if opErr.Token != "rrx" || opErr.Line != 3 {
+			t.Errorf("Expected rrx on line 3, got %s on line %d", opErr.Token, opErr.Line)
+		}
+	}
+}
+
+func TestOperationScannerStopsAfterError(t *testing.T) {
+	scanner := NewOperationScanner(strings.NewReader("bad\nsa\n"), false)
+	if scanner.Scan() {
+		t.Fatal("Expected Scan to fail on the first line")
+	}
+	if scanner.Scan() {
+		t.Error("Scan should keep failing after an error")
+	}
+	if scanner.Line() != 1 {
+		t.Errorf("Expected line 1, got %d", scanner.Line())
+	}
+}
@@ -0,0 +1,190 @@
+package solver
+
+import (
+	"context"
+	"math/rand"
+	"push-swap/internal/operations"
+	"push-swap/internal/optimizer"
+	"time"
+)
+
+// anytimeSeed seeds the random restarts of SolveWithContext so that a
+// given amount of search always explores the same parameters
+const anytimeSeed = 1
+
+// anytimeMaxChunks bounds the chunk count of a random restart, unless
+// the defaults for the input size already use more than half of it
+const anytimeMaxChunks = 40
+
+// SolveWithContext solves like Solve, then spends up to budget improving
+// the program by local search within the solver's strategy. Every strategy
+// has each run of rotations in its program shortened to its net effect.
+// The chunk strategies also restart the chunk strategy with random
+// parameters, and auto, which picks a strategy itself, additionally runs
+// the strategy portfolio. The best program found so far is returned as
+// soon as budget elapses or ctx is done, which also cancels the attempt
+// in progress; Solve itself always runs to completion.
+//
+// Without a positive budget the search is bounded by ctx alone, and when
+// ctx cannot be cancelled either SolveWithContext returns the program of
+// Solve immediately. Programs that are already optimal, those of the
+// optimal strategy and of auto for inputs it hands to it, are never
+// searched, as no shorter program exists.
+func (s *Solver) SolveWithContext(ctx context.Context, budget time.Duration) []operations.Operation {
+	if budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
+
+	input := s.stackA.ToSlice()
+	start := len(s.operations)
+	best := s.Solve()[start:]
+	if ctx.Done() == nil || len(best) == 0 || s.solvesOptimally(len(input)) {
+		return s.operations
+	}
+
+	best = improveProgram(ctx, input, best, s.strategy.Name())
+	s.operations = append(s.operations[:start], best...)
+	return s.operations
+}
+
+// solvesOptimally reports whether the solver's strategy finds the shortest
+// program for an input of the given size
+func (s *Solver) solvesOptimally(size int) bool {
+	switch s.strategy.Name() {
+	case StrategyOptimal:
+		return true
+	case StrategyAuto:
+		return size <= autoOptimalSize
+	}
+	return false
+}
+
+// improveProgram searches for a program shorter than best that sorts
+// input until ctx is done, trying the alternatives that the named
+// strategy allows
+func improveProgram(ctx context.Context, input []int, best []operations.Operation, strategy string) []operations.Operation {
+	consider := func(program []operations.Operation) {
+		program = optimizer.Optimize(compressRotations(input, program))
+		if len(program) < len(best) && Verify(input, program) == nil {
+			best = program
+		}
+	}
+	consider(best)
+
+	switch strategy {
+	case StrategyAuto:
+		if result, err := SolvePortfolio(ctx, input, 0); err == nil {
+			consider(result.Operations)
+		}
+	case StrategyChunk, StrategyChunkAuto:
+	default:
+		return best
+	}
+
+	// Alternate random restarts with small moves around the best chunk
+	// parameters seen so far
+	rng := rand.New(rand.NewSource(anytimeSeed))
+	n := len(input)
+	chunkParams := DefaultChunkParams(n)
+	chunkOps := -1
+	maxChunks := min(n, max(anytimeMaxChunks, 2*numChunks(n, chunkParams.ChunkSize)))
+	for round := 0; ctx.Err() == nil; round++ {
+		chunks := 1 + rng.Intn(maxChunks)
+		params := ChunkParams{ChunkSize: chunkSizeFor(n, chunks), Split: rng.Float64()}
+		if round%2 == 1 {
+			step := max(1, chunkParams.ChunkSize/10)
+			params = ChunkParams{
+				ChunkSize: min(max(chunkParams.ChunkSize+rng.Intn(2*step+1)-step, 1), n),
+				Split:     min(max(chunkParams.Split+rng.Float64()*0.2-0.1, 0), 1),
+			}
+		}
+
+		program, err := solveChunkContext(ctx, input, params)
+		if err != nil {
+			break
+		}
+		if chunkOps < 0 || len(program) < chunkOps {
+			chunkParams, chunkOps = params, len(program)
+		}
+		consider(program)
+	}
+	return best
+}
+
+// compressRotations replaces every run of consecutive rotations in a
+// program sorting input by the shortest run with the same net effect,
+// using the sizes of both stacks at that point of the program
+func compressRotations(input []int, program []operations.Operation) []operations.Operation {
+	sizeA, sizeB := len(input), 0
+	result := make([]operations.Operation, 0, len(program))
+
+	for i := 0; i < len(program); {
+		j, netA, netB := i, 0, 0
+		for ; j < len(program); j++ {
+			dA, dB, ok := rotationEffect(program[j])
+			if !ok {
+				break
+			}
+			netA += dA
+			netB += dB
+		}
+
+		if j == i {
+			switch program[i] {
+			case operations.PA:
+				sizeA, sizeB = sizeA+1, sizeB-1
+			case operations.PB:
+				sizeA, sizeB = sizeA-1, sizeB+1
+			}
+			result = append(result, program[i])
+			i++
+			continue
+		}
+
+		if run := shortestRotation(netA, sizeA, netB, sizeB); len(run) < j-i {
+			result = append(result, run...)
+		} else {
+			result = append(result, program[i:j]...)
+		}
+		i = j
+	}
+	return result
+}
+
+// rotationEffect returns how far op rotates stacks A and B upwards, or
+// false if op is not a rotation
+func rotationEffect(op operations.Operation) (int, int, bool) {
+	switch op {
+	case operations.RA:
+		return 1, 0, true
+	case operations.RB:
+		return 0, 1, true
+	case operations.RR:
+		return 1, 1, true
+	case operations.RRA:
+		return -1, 0, true
+	case operations.RRB:
+		return 0, -1, true
+	case operations.RRR:
+		return -1, -1, true
+	}
+	return 0, 0, false
+}
+
+// shortestRotation returns the shortest run of rotations that turns a
+// stack of sizeA elements upwards by netA positions and one of sizeB
+// elements by netB
+func shortestRotation(netA, sizeA, netB, sizeB int) []operations.Operation {
+	return rotationRun(combineRotations(rotationOffset(netA, sizeA), sizeA, rotationOffset(netB, sizeB), sizeB))
+}
+
+// rotationOffset returns the index brought to the top of a stack of size
+// elements by rotating it upwards net times
+func rotationOffset(net, size int) int {
+	if size <= 1 {
+		return 0
+	}
+	return (net%size + size) % size
+}
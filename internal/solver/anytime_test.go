@@ -0,0 +1,221 @@
+package solver
+
+import (
+	"context"
+	"math/rand"
+	"push-swap/internal/operations"
+	"push-swap/internal/optimizer"
+	"push-swap/internal/stack"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSolveWithContextImmediate(t *testing.T) {
+	input := rand.New(rand.NewSource(25)).Perm(100)
+	expected := NewSolver(input).Solve()
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		budget time.Duration
+	}{
+		{"No budget", context.Background(), 0},
+		{"Cancelled context", cancelled, 0},
+		{"Cancelled context with budget", cancelled, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			got := NewSolver(input).SolveWithContext(tt.ctx, tt.budget)
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("Expected an immediate result, took %v", elapsed)
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected the program of Solve (%d ops), got %d ops", len(expected), len(got))
+			}
+		})
+	}
+}
+
+func TestSolveWithContextBudget(t *testing.T) {
+	input := rand.New(rand.NewSource(26)).Perm(100)
+	immediate := len(NewSolver(input).Solve())
+
+	start := time.Now()
+	got := NewSolver(input).SolveWithContext(context.Background(), 200*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the budget to end the search, took %v", elapsed)
+	}
+	if !validateSolution(input, got) {
+		t.Fatal("Solution should result in sorted stack")
+	}
+	if len(got) > immediate {
+		t.Errorf("Expected at most %d ops, got %d", immediate, len(got))
+	}
+}
+
+func TestSolveWithContextSkipsOptimalPrograms(t *testing.T) {
+	tests := []struct {
+		input    []int
+		strategy string
+	}{
+		{[]int{}, StrategyAuto},
+		{[]int{1, 2, 3}, StrategyAuto},
+		{[]int{3, 1, 2}, StrategyAuto},
+		{[]int{5, 4, 3, 2, 1}, StrategyAuto},
+		{[]int{7, 3, 6, 1, 5, 2, 4}, StrategyOptimal},
+	}
+
+	for _, tt := range tests {
+		s, err := NewSolverWithStrategy(tt.input, tt.strategy)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		start := time.Now()
+		got := s.SolveWithContext(context.Background(), time.Minute)
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Expected no search for %v with %s, took %v", tt.input, tt.strategy, elapsed)
+		}
+		expected, _ := NewSolverWithStrategy(tt.input, tt.strategy)
+		if want := expected.Solve(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestSolveWithContextKeepsStrategy(t *testing.T) {
+	input := rand.New(rand.NewSource(27)).Perm(100)
+
+	for _, name := range []string{StrategyTurk, StrategyRadix, StrategyLIS} {
+		t.Run(name, func(t *testing.T) {
+			s, _ := NewSolverWithStrategy(input, name)
+			plain := s.Solve()
+
+			s, _ = NewSolverWithStrategy(input, name)
+			start := time.Now()
+			got := s.SolveWithContext(context.Background(), time.Minute)
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("Expected only the program of %s to be shortened, took %v", name, elapsed)
+			}
+
+			// Only rotations are compressed, so every push of the strategy
+			// is kept in order
+			expected := optimizer.Optimize(compressRotations(input, plain))
+			if len(expected) > len(plain) {
+				expected = plain
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected the compressed program of %s (%d ops), got %d ops", name, len(expected), len(got))
+			}
+		})
+	}
+}
+
+func TestSolveWithContextStopsChunkRestarts(t *testing.T) {
+	input := rand.New(rand.NewSource(28)).Perm(3000)
+	s, _ := NewSolverWithStrategy(input, StrategyChunk)
+
+	start := time.Now()
+	got := s.SolveWithContext(context.Background(), 100*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the budget to cancel the restart in progress, took %v", elapsed)
+	}
+	if !validateSolution(input, got) {
+		t.Fatal("Solution should result in sorted stack")
+	}
+}
+
+func TestCompressRotations(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		program  []operations.Operation
+		expected []operations.Operation
+	}{
+		{
+			"Long run turns the other way",
+			5,
+			[]operations.Operation{operations.RA, operations.RA, operations.RA, operations.RA},
+			[]operations.Operation{operations.RRA},
+		},
+		{
+			"Full turn disappears",
+			3,
+			[]operations.Operation{operations.RA, operations.SA, operations.RRA, operations.RRA, operations.RRA},
+			[]operations.Operation{operations.RA, operations.SA},
+		},
+		{
+			"Both stacks share rrr",
+			5,
+			[]operations.Operation{operations.PB, operations.PB, operations.RA, operations.RB, operations.RA},
+			[]operations.Operation{operations.PB, operations.PB, operations.RRR},
+		},
+		{
+			"Rotating an empty stack does nothing",
+			5,
+			[]operations.Operation{operations.RA, operations.RB, operations.PB, operations.RRA},
+			[]operations.Operation{operations.RA, operations.PB, operations.RRA},
+		},
+		{
+			"Short runs are kept",
+			5,
+			[]operations.Operation{operations.RA, operations.SA, operations.RRA, operations.RRA},
+			[]operations.Operation{operations.RA, operations.SA, operations.RRA, operations.RRA},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := rand.New(rand.NewSource(int64(tt.size))).Perm(tt.size)
+			got := compressRotations(input, tt.program)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCompressRotationsPreservesFinalState(t *testing.T) {
+	rng := rand.New(rand.NewSource(27))
+	run := func(input []int, program []operations.Operation) ([]int, []int) {
+		a, b := stack.NewStack(input), stack.NewEmptyStack()
+		for _, op := range program {
+			operations.ExecuteOperation(a, b, op)
+		}
+		return a.ToSlice(), b.ToSlice()
+	}
+
+	for i := 0; i < 200; i++ {
+		input := rng.Perm(2 + rng.Intn(8))
+		program := make([]operations.Operation, 0, 40)
+		sizeB := 0
+		for len(program) < cap(program) {
+			op := operations.AllOperations[rng.Intn(len(operations.AllOperations))]
+			// Keep every push valid so that stack sizes stay known
+			if (op == operations.PA && sizeB == 0) || (op == operations.PB && sizeB == len(input)) {
+				continue
+			}
+			if op == operations.PA {
+				sizeB--
+			} else if op == operations.PB {
+				sizeB++
+			}
+			program = append(program, op)
+		}
+
+		got := compressRotations(input, program)
+		if len(got) > len(program) {
+			t.Fatalf("Expected at most %d ops, got %d", len(program), len(got))
+		}
+		wantA, wantB := run(input, program)
+		gotA, gotB := run(input, got)
+		if !reflect.DeepEqual(gotA, wantA) || !reflect.DeepEqual(gotB, wantB) {
+			t.Fatalf("Program %v on %v: expected stacks %v %v, got %v %v", program, input, wantA, wantB, gotA, gotB)
+		}
+	}
+}
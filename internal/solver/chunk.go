@@ -0,0 +1,187 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"push-swap/internal/operations"
+	"push-swap/internal/optimizer"
+)
+
+// ChunkParams configures the chunk strategy. The ranges are given by their
+// size rather than their number so that the defaults reproduce the fixed
+// ranges of 20, 30 and 45 ranks the strategy always used; numChunks and
+// chunkSizeFor convert between the two.
+type ChunkParams struct {
+	// ChunkSize is the number of consecutive ranks in each range pushed
+	// to B, one range after another; the last range may be smaller
+	ChunkSize int
+	// Split is the fraction of each range, from its low end, that is
+	// rotated to the bottom of B after being pushed. 0 never rotates,
+	// 1 rotates every element.
+	Split float64
+}
+
+func (p ChunkParams) String() string {
+	return fmt.Sprintf("chunks of %d, split %.2f", p.ChunkSize, p.Split)
+}
+
+// DefaultChunkParams returns the fixed parameters used by the chunk
+// strategy: ranges of about 20 ranks up to 100 elements, 30 up to 500
+// and 45 beyond, each split in half
+func DefaultChunkParams(size int) ChunkParams {
+	chunkSize := 45
+	if size <= 100 {
+		chunkSize = 20
+	} else if size <= 500 {
+		chunkSize = 30
+	}
+	return ChunkParams{ChunkSize: chunkSize, Split: 0.5}
+}
+
+// ChunkCandidates returns the parameters tried by the chunk-auto
+// strategy for an input of the given size, starting with the defaults so
+// that tuning never does worse than the chunk strategy. The others split
+// the input into 2 to 20 ranges of equal size.
+func ChunkCandidates(size int) []ChunkParams {
+	candidates := []ChunkParams{DefaultChunkParams(size)}
+	for _, chunks := range []int{2, 3, 4, 5, 6, 8, 10, 12, 14, 16, 20} {
+		if chunks > size {
+			break
+		}
+		for _, split := range []float64{0, 0.25, 0.5, 0.75} {
+			candidates = append(candidates, ChunkParams{ChunkSize: chunkSizeFor(size, chunks), Split: split})
+		}
+	}
+	return candidates
+}
+
+// chunkSizeFor returns the size of the ranges that split size ranks into
+// the given number of chunks
+func chunkSizeFor(size, chunks int) int {
+	return (size + chunks - 1) / chunks
+}
+
+// numChunks returns the number of ranges of chunkSize ranks needed to
+// cover size ranks
+func numChunks(size, chunkSize int) int {
+	return (size + chunkSize - 1) / chunkSize
+}
+
+// SolveChunk sorts input with the chunk strategy and the given parameters
+// and returns the optimized program
+func SolveChunk(input []int, params ChunkParams) []operations.Operation {
+	ops, _ := solveChunkContext(context.Background(), input, params)
+	return ops
+}
+
+// solveChunkContext is SolveChunk returning the error of ctx instead of a
+// program once ctx is done
+func solveChunkContext(ctx context.Context, input []int, params ChunkParams) ([]operations.Operation, error) {
+	s := NewSolver(input)
+	s.ctx = ctx
+	if !s.stackA.IsSorted() {
+		s.solveChunk(params)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return optimizer.Optimize(s.operations), nil
+}
+
+// TuneChunkParams simulates every candidate from ChunkCandidates on input
+// and returns the parameters giving the shortest program, preferring the
+// earlier candidate on ties, along with the length of that program
+func TuneChunkParams(input []int) (ChunkParams, int) {
+	return tuneChunkParams(context.Background(), input)
+}
+
+// tuneChunkParams is TuneChunkParams stopping at the best candidate so
+// far once ctx is done
+func tuneChunkParams(ctx context.Context, input []int) (ChunkParams, int) {
+	best := DefaultChunkParams(len(input))
+	bestOps := -1
+	for _, params := range ChunkCandidates(len(input)) {
+		program, err := solveChunkContext(ctx, input, params)
+		if err != nil {
+			break
+		}
+		if bestOps < 0 || len(program) < bestOps {
+			best, bestOps = params, len(program)
+		}
+	}
+	return best, bestOps
+}
+
+// solveLargeOptimized sorts with the chunk strategy and its fixed
+// parameters
+func (s *Solver) solveLargeOptimized() {
+	s.solveChunk(DefaultChunkParams(s.stackA.Size()))
+}
+
+// solveChunkAuto sorts with the chunk parameters that work best on this
+// input
+func (s *Solver) solveChunkAuto() {
+	params, _ := tuneChunkParams(s.ctx, s.stackA.ToSlice())
+	s.solveChunk(params)
+}
+
+// solveChunk pushes the ranks to B one range at a time, rotating A until
+// every element of the current range is pushed and sinking the lower part
+// of each range to the bottom of B, then pushes everything back from B to
+// A, largest first
+func (s *Solver) solveChunk(params ChunkParams) {
+	size := s.stackA.Size()
+
+	// Convert to ranks for easier handling
+	ranks := s.createRanks()
+	s.applyRanks(ranks)
+
+	chunkSize := max(1, params.ChunkSize)
+	chunks := numChunks(size, chunkSize)
+
+	// Push all elements to B in chunks
+	for chunk := 0; chunk < chunks; chunk++ {
+		if s.cancelled() {
+			return
+		}
+		minRange := chunk * chunkSize
+		maxRange := min((chunk+1)*chunkSize, size)
+		splitRank := minRange + int(params.Split*float64(maxRange-minRange))
+
+		// Push all elements in this range
+		elementsInChunk := maxRange - minRange
+		rotations := 0
+
+		for elementsInChunk > 0 && rotations < size*2 { // Safety limit
+			if s.stackA.IsEmpty() {
+				break
+			}
+
+			top, _ := s.stackA.Top()
+
+			if top >= minRange && top < maxRange {
+				s.executeAndRecord(operations.PB)
+				elementsInChunk--
+
+				// Smart rotation in B
+				if s.stackB.Size() > 1 && top < splitRank {
+					s.executeAndRecord(operations.RB)
+				}
+			} else {
+				s.executeAndRecord(operations.RA)
+			}
+
+			rotations++
+		}
+	}
+
+	// Push everything back from B to A (largest first)
+	for s.stackB.Size() > 0 {
+		if s.cancelled() {
+			return
+		}
+		maxPos := s.findMaxPosition(s.stackB)
+		s.moveToTopOptimized(s.stackB, maxPos, false)
+		s.executeAndRecord(operations.PA)
+	}
+}
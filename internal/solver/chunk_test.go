@@ -0,0 +1,125 @@
+package solver
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDefaultChunkParams(t *testing.T) {
+	tests := []struct {
+		size      int
+		chunkSize int
+	}{
+		{1, 20},
+		{100, 20},
+		{101, 30},
+		{500, 30},
+		{501, 45},
+	}
+
+	for _, tt := range tests {
+		params := DefaultChunkParams(tt.size)
+		if params.ChunkSize != tt.chunkSize || params.Split != 0.5 {
+			t.Errorf("Size %d: expected chunks of %d split 0.50, got %v", tt.size, tt.chunkSize, params)
+		}
+	}
+}
+
+func TestChunkConversions(t *testing.T) {
+	tests := []struct {
+		size      int
+		chunkSize int
+		chunks    int
+	}{
+		{100, 20, 5},
+		{100, 30, 4},
+		{10, 4, 3},
+		{1, 20, 1},
+	}
+
+	for _, tt := range tests {
+		if got := numChunks(tt.size, tt.chunkSize); got != tt.chunks {
+			t.Errorf("Expected %d ranks in ranges of %d to need %d ranges, got %d", tt.size, tt.chunkSize, tt.chunks, got)
+		}
+		// Ranges of the returned size never exceed the requested count
+		if got := numChunks(tt.size, chunkSizeFor(tt.size, tt.chunks)); got > tt.chunks {
+			t.Errorf("Expected at most %d ranges for %d ranks, got %d", tt.chunks, tt.size, got)
+		}
+	}
+}
+
+func TestChunkStrategyProgramLength(t *testing.T) {
+	// Lengths of the chunk strategy before it was parameterised, which the
+	// defaults must keep reproducing
+	tests := []struct {
+		size int
+		ops  int
+	}{
+		{100, 609},
+		{101, 628},
+		{500, 6233},
+		{501, 5691},
+		{1000, 16580},
+	}
+
+	for _, tt := range tests {
+		input := rand.New(rand.NewSource(int64(tt.size))).Perm(tt.size)
+		s, _ := NewSolverWithStrategy(input, StrategyChunk)
+		if got := len(s.Solve()); got != tt.ops {
+			t.Errorf("Size %d: expected %d ops, got %d", tt.size, tt.ops, got)
+		}
+	}
+}
+
+func TestSolveChunkWithEveryCandidate(t *testing.T) {
+	rng := rand.New(rand.NewSource(23))
+	for _, size := range []int{2, 7, 64, 150} {
+		input := rng.Perm(size)
+		for _, params := range ChunkCandidates(size) {
+			if ops := SolveChunk(input, params); !validateSolution(input, ops) {
+				t.Fatalf("Chunk %v should sort %v", params, input)
+			}
+		}
+	}
+}
+
+func TestSolveChunkSplitExtremes(t *testing.T) {
+	input := rand.New(rand.NewSource(4)).Perm(60)
+	for _, split := range []float64{0, 1} {
+		params := ChunkParams{ChunkSize: 15, Split: split}
+		if ops := SolveChunk(input, params); !validateSolution(input, ops) {
+			t.Errorf("Chunk %v should sort the input", params)
+		}
+	}
+}
+
+func TestSolveChunkMatchesStrategy(t *testing.T) {
+	input := rand.New(rand.NewSource(5)).Perm(100)
+
+	s, _ := NewSolverWithStrategy(input, StrategyChunk)
+	if got, want := len(SolveChunk(input, DefaultChunkParams(100))), len(s.Solve()); got != want {
+		t.Errorf("Expected SolveChunk with the defaults to match the chunk strategy, got %d and %d ops", got, want)
+	}
+}
+
+func TestTuneChunkParams(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+	for _, size := range []int{50, 200} {
+		input := rng.Perm(size)
+
+		params, tunedOps := TuneChunkParams(input)
+		defaultOps := len(SolveChunk(input, DefaultChunkParams(size)))
+		if tunedOps > defaultOps {
+			t.Errorf("Size %d: tuned %v uses %d ops, more than the default %d", size, params, tunedOps, defaultOps)
+		}
+
+		s, _ := NewSolverWithStrategy(input, StrategyChunkAuto)
+		ops := s.Solve()
+		if !validateSolution(input, ops) {
+			t.Fatalf("chunk-auto should sort %v", input)
+		}
+		if len(ops) != tunedOps {
+			t.Errorf("Size %d: expected chunk-auto to use the tuned %d ops, got %d", size, tunedOps, len(ops))
+		}
+	}
+}
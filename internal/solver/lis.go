@@ -0,0 +1,153 @@
+package solver
+
+import (
+	"push-swap/internal/operations"
+	"sort"
+)
+
+// solveLIS keeps the longest increasing circular subsequence of A in
+// place and pushes only the other elements to B, then inserts them back
+// by cost as solveLargeTurk does. The kept elements form a rotated sorted
+// sequence, so on nearly sorted input most of A never moves.
+func (s *Solver) solveLIS() {
+	size := s.stackA.Size()
+
+	ranks := s.createRanks()
+	s.applyRanks(ranks)
+
+	keep := make([]bool, size)
+	for _, rank := range longestCircularIncreasing(s.stackA.ToSlice()) {
+		keep[rank] = true
+	}
+
+	// Push the rest, nearest first, sinking the lower half in B as
+	// solveLargeTurk does
+	mid := size / 2
+	for pos := s.nearestUnkept(keep); pos >= 0; pos = s.nearestUnkept(keep) {
+		if s.cancelled() {
+			return
+		}
+		s.moveToTopOptimized(s.stackA, pos, true)
+		top, _ := s.stackA.Top()
+		s.executeAndRecord(operations.PB)
+		if top < mid && s.stackB.Size() > 1 {
+			s.executeAndRecord(operations.RB)
+		}
+	}
+
+	for !s.stackB.IsEmpty() {
+		if s.cancelled() {
+			return
+		}
+		move := s.cheapestInsertion()
+		s.applyInsertion(move)
+		s.executeAndRecord(operations.PA)
+	}
+
+	minPos := s.findMinPosition(s.stackA)
+	s.moveToTopOptimized(s.stackA, minPos, true)
+}
+
+// nearestUnkept returns the position in A of the element that is not kept
+// and needs the fewest rotations to reach the top, or -1 if every
+// element is kept
+func (s *Solver) nearestUnkept(keep []bool) int {
+	a := s.stackA.ToSlice()
+	best, bestCost := -1, 0
+	for i, rank := range a {
+		if keep[rank] {
+			continue
+		}
+		cost := min(i, len(a)-i)
+		if best < 0 || cost < bestCost {
+			best, bestCost = i, cost
+		}
+	}
+	return best
+}
+
+// lisMaxStarts bounds the starting positions longestCircularIncreasing
+// tries. Random input has a run start at about every other position, while
+// the nearly sorted input this strategy is meant for has few.
+const lisMaxStarts = 256
+
+// longestCircularIncreasing returns the values of a longest increasing
+// subsequence of a read circularly from any starting position. With more
+// than lisMaxStarts candidate starts it only tries evenly spaced ones and
+// may return a slightly shorter subsequence.
+func longestCircularIncreasing(a []int) []int {
+	n := len(a)
+
+	// A subsequence read from inside an ascending run can also be read
+	// from the start of that run, whose earlier elements are smaller than
+	// its first element, so only run starts need to be tried
+	var starts []int
+	for start := range a {
+		if n == 1 || a[(start+n-1)%n] > a[start] {
+			starts = append(starts, start)
+		}
+	}
+	step := (len(starts) + lisMaxStarts - 1) / lisMaxStarts
+
+	rotated := make([]int, n)
+	tails := make([]int, 0, n)
+	bestStart, bestLen := 0, -1
+	for i := 0; i < len(starts); i += step {
+		rotateInto(rotated, a, starts[i])
+		if length := increasingLength(rotated, tails); length > bestLen {
+			bestStart, bestLen = starts[i], length
+		}
+	}
+
+	rotateInto(rotated, a, bestStart)
+	return longestIncreasing(rotated)
+}
+
+// rotateInto copies a into dst starting from index start, wrapping around
+func rotateInto(dst, a []int, start int) {
+	copy(dst, a[start:])
+	copy(dst[len(a)-start:], a[:start])
+}
+
+// increasingLength returns the length of a longest strictly increasing
+// subsequence of a, using tails as scratch space
+func increasingLength(a, tails []int) int {
+	tails = tails[:0] // smallest tail value of each length
+	for _, v := range a {
+		n := sort.SearchInts(tails, v)
+		if n == len(tails) {
+			tails = append(tails, v)
+		} else {
+			tails[n] = v
+		}
+	}
+	return len(tails)
+}
+
+// longestIncreasing returns the values of a longest strictly increasing
+// subsequence of a, using patience sorting
+func longestIncreasing(a []int) []int {
+	tails := make([]int, 0, len(a)) // indices of the smallest tail of each length
+	parent := make([]int, len(a))   // previous index in the subsequence ending here
+	for i, v := range a {
+		n := sort.Search(len(tails), func(k int) bool { return a[tails[k]] >= v })
+		parent[i] = -1
+		if n > 0 {
+			parent[i] = tails[n-1]
+		}
+		if n == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[n] = i
+		}
+	}
+
+	result := make([]int, len(tails))
+	if len(tails) == 0 {
+		return result
+	}
+	for k, i := len(tails)-1, tails[len(tails)-1]; k >= 0; k, i = k-1, parent[i] {
+		result[k] = a[i]
+	}
+	return result
+}
@@ -0,0 +1,126 @@
+package solver
+
+import (
+	"math/rand"
+	"push-swap/internal/gen"
+	"reflect"
+	"testing"
+)
+
+func TestLongestIncreasing(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []int
+		expected []int
+	}{
+		{"Empty", []int{}, []int{}},
+		{"Sorted", []int{0, 1, 2, 3}, []int{0, 1, 2, 3}},
+		{"Reversed", []int{3, 2, 1, 0}, []int{0}},
+		{"Mixed", []int{2, 0, 3, 1, 4, 5}, []int{0, 1, 4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := longestIncreasing(tt.input); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLongestCircularIncreasing(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []int
+		length int
+	}{
+		{"Rotated sorted", []int{3, 4, 5, 0, 1, 2}, 6},
+		{"Wraps around", []int{4, 9, 0, 7, 1, 2, 3, 5, 8, 6}, 7},
+		{"Reversed", []int{4, 3, 2, 1, 0}, 2},
+		{"Single element", []int{0}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := longestCircularIncreasing(tt.input); len(got) != tt.length {
+				t.Errorf("Expected length %d, got %v", tt.length, got)
+			}
+		})
+	}
+}
+
+func TestLongestCircularIncreasingMatchesEveryRotation(t *testing.T) {
+	rng := rand.New(rand.NewSource(23))
+	for trial := 0; trial < 300; trial++ {
+		a := rng.Perm(1 + rng.Intn(40))
+
+		// Trying every rotation is the definition the run starts shortcut
+		// must agree with
+		expected := 0
+		rotated := make([]int, len(a))
+		for start := range a {
+			rotateInto(rotated, a, start)
+			expected = max(expected, increasingLength(rotated, nil))
+		}
+
+		got := longestCircularIncreasing(a)
+		if len(got) != expected {
+			t.Fatalf("Expected length %d for %v, got %v", expected, a, got)
+		}
+		for i := 1; i < len(got); i++ {
+			if got[i-1] >= got[i] {
+				t.Fatalf("Expected an increasing subsequence of %v, got %v", a, got)
+			}
+		}
+	}
+}
+
+func TestSolveLIS(t *testing.T) {
+	rng := rand.New(rand.NewSource(22))
+	for _, size := range []int{2, 3, 5, 10, 100, 500} {
+		for trial := 0; trial < 3; trial++ {
+			input := rng.Perm(size)
+			solver := NewSolver(input)
+			solver.solveLIS()
+
+			if !validateSolution(input, solver.operations) {
+				t.Fatalf("Solution for %v should result in sorted stack", input)
+			}
+		}
+	}
+}
+
+func TestSolveLISRotatedInputOnlyRotates(t *testing.T) {
+	input := []int{40, 50, 60, 10, 20, 30}
+	solver := NewSolver(input)
+	solver.solveLIS()
+
+	for _, op := range solver.operations {
+		if op != "ra" && op != "rra" {
+			t.Fatalf("Expected only rotations for a rotated sorted input, got %v", solver.operations)
+		}
+	}
+	if len(solver.operations) != 3 {
+		t.Errorf("Expected 3 rotations, got %d", len(solver.operations))
+	}
+}
+
+func TestSolveLISBeatsChunkOnNearlySorted(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		input, err := gen.Generate("nearly", 100, seed, gen.Options{Swaps: 10})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		lis, _ := NewSolverWithStrategy(input, StrategyLIS)
+		chunk, _ := NewSolverWithStrategy(input, StrategyChunk)
+		lisOps, chunkOps := lis.Solve(), chunk.Solve()
+
+		if !validateSolution(input, lisOps) {
+			t.Fatalf("Solution for %v should result in sorted stack", input)
+		}
+		if len(lisOps) >= len(chunkOps) {
+			t.Errorf("Seed %d: expected lis (%d ops) to beat chunk (%d ops)", seed, len(lisOps), len(chunkOps))
+		}
+	}
+}
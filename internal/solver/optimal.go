@@ -0,0 +1,142 @@
+package solver
+
+import (
+	"fmt"
+	"push-swap/internal/operations"
+	"push-swap/internal/stack"
+)
+
+// MaxOptimalSize is the largest input SolveOptimal will search. The state
+// space grows as n!·(n+1), so beyond this a search takes too long.
+const MaxOptimalSize = 7
+
+// autoOptimalSize is the largest input the auto strategy searches for an
+// optimal program; searching seven elements takes up to a tenth of a second
+const autoOptimalSize = 6
+
+// searchNode records how a state was first reached during the search
+type searchNode struct {
+	parent uint64
+	op     operations.Operation
+}
+
+// SolveOptimal returns a shortest program that sorts input, found by a
+// breadth-first search over (stack A, stack B) states using every valid
+// operation. Inputs larger than MaxOptimalSize are rejected.
+func SolveOptimal(input []int) ([]operations.Operation, error) {
+	n := len(input)
+	if n > MaxOptimalSize {
+		return nil, fmt.Errorf("optimal search supports at most %d elements, got %d", MaxOptimalSize, n)
+	}
+
+	s := NewSolver(input)
+	if s.stackA.IsSorted() {
+		return []operations.Operation{}, nil
+	}
+	s.applyRanks(s.createRanks())
+
+	return searchOptimal(s.stackA.ToSlice()), nil
+}
+
+// searchOptimal runs the breadth-first search on a permutation of 0..n-1
+func searchOptimal(ranks []int) []operations.Operation {
+	n := len(ranks)
+	goalRanks := make([]int, n)
+	for i := range goalRanks {
+		goalRanks[i] = i
+	}
+
+	start := encodeState(ranks, nil)
+	goal := encodeState(goalRanks, nil)
+
+	visited := map[uint64]searchNode{start: {}}
+	frontier := []uint64{start}
+
+	for len(frontier) > 0 {
+		var next []uint64
+		for _, key := range frontier {
+			a, b := decodeState(key, n)
+			for _, op := range operations.AllOperations {
+				stackA := stack.NewStack(a)
+				stackB := stack.NewStack(b)
+				if err := operations.ExecuteOperation(stackA, stackB, op); err != nil {
+					continue
+				}
+
+				child := encodeState(stackA.ToSlice(), stackB.ToSlice())
+				if _, seen := visited[child]; seen {
+					continue
+				}
+				visited[child] = searchNode{parent: key, op: op}
+
+				if child == goal {
+					return reconstructPath(visited, start, goal)
+				}
+				next = append(next, child)
+			}
+		}
+		frontier = next
+	}
+
+	// Every permutation is reachable, so this only happens for a sorted input
+	return []operations.Operation{}
+}
+
+// reconstructPath walks parent links back from goal to start
+func reconstructPath(visited map[uint64]searchNode, start, goal uint64) []operations.Operation {
+	var reversed []operations.Operation
+	for key := goal; key != start; key = visited[key].parent {
+		reversed = append(reversed, visited[key].op)
+	}
+
+	ops := make([]operations.Operation, len(reversed))
+	for i, op := range reversed {
+		ops[len(reversed)-1-i] = op
+	}
+	return ops
+}
+
+// encodeState packs a state into a single integer: the size of A in the
+// low four bits followed by the ranks of A and then B, four bits each
+func encodeState(a, b []int) uint64 {
+	key := uint64(len(a))
+	shift := 4
+	for _, v := range a {
+		key |= uint64(v) << shift
+		shift += 4
+	}
+	for _, v := range b {
+		key |= uint64(v) << shift
+		shift += 4
+	}
+	return key
+}
+
+// decodeState unpacks a state created by encodeState for n elements
+func decodeState(key uint64, n int) ([]int, []int) {
+	sizeA := int(key & 0xf)
+	values := make([]int, n)
+	for i := range values {
+		values[i] = int(key >> (4 * (i + 1)) & 0xf)
+	}
+	return values[:sizeA], values[sizeA:]
+}
+
+// solveOptimal sorts stack A with a shortest program, taken from the
+// embedded table when it covers the input and searched for otherwise
+func (s *Solver) solveOptimal() {
+	if s.stackA.Size() > MaxOptimalSize {
+		return
+	}
+	
+	s.applyRanks(s.createRanks())
+	ranks := s.stackA.ToSlice()
+	
+	ops, ok := lookupOptimal(ranks)
+	if !ok {
+		ops = searchOptimal(ranks)
+	}
+	for _, op := range ops {
+		s.executeAndRecord(op)
+	}
+}
@@ -0,0 +1,125 @@
+package solver
+
+import (
+	"testing"
+)
+
+func TestSolveOptimalAllPermutations(t *testing.T) {
+	for n := 1; n <= 6; n++ {
+		worst := 0
+		for _, perm := range permutations(n) {
+			ops, err := SolveOptimal(perm)
+			if err != nil {
+				t.Fatalf("Unexpected error for %v: %v", perm, err)
+			}
+
+			if !validateSolution(perm, ops) {
+				t.Fatalf("Solution %v for %v should result in sorted stack", ops, perm)
+			}
+
+			// A shortest program can never be longer than the heuristic one
+			heuristic := NewSolver(perm)
+			heuristic.solveSmall()
+			if len(ops) > len(heuristic.operations) {
+				t.Errorf("Optimal solution for %v uses %d operations, heuristic uses %d",
+					perm, len(ops), len(heuristic.operations))
+			}
+
+			if len(ops) > worst {
+				worst = len(ops)
+			}
+		}
+		t.Logf("n=%d: worst case %d operations", n, worst)
+
+		if n == 3 && worst != 2 {
+			t.Errorf("Expected worst case of 2 operations for 3 elements, got %d", worst)
+		}
+		if n == 5 && worst > 12 {
+			t.Errorf("Expected worst case <= 12 operations for 5 elements, got %d", worst)
+		}
+	}
+}
+
+func TestSolveOptimalKnownCases(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []int
+		expected int
+	}{
+		{"Sorted", []int{1, 2, 3}, 0},
+		{"Swap", []int{2, 1}, 1},
+		{"Rotate", []int{2, 3, 1}, 1},
+		{"Reverse three", []int{3, 2, 1}, 2},
+		{"Arbitrary values", []int{-10, 400, 7}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := SolveOptimal(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(ops) != tt.expected {
+				t.Errorf("Expected %d operations, got %d (%v)", tt.expected, len(ops), ops)
+			}
+			if !validateSolution(tt.input, ops) {
+				t.Error("Solution should result in sorted stack")
+			}
+		})
+	}
+}
+
+func TestSolveOptimalSeven(t *testing.T) {
+	input := []int{7, 6, 5, 4, 3, 2, 1}
+	ops, err := SolveOptimal(input)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !validateSolution(input, ops) {
+		t.Error("Solution should result in sorted stack")
+	}
+}
+
+func TestSolveOptimalTooLarge(t *testing.T) {
+	input := make([]int, MaxOptimalSize+1)
+	for i := range input {
+		input[i] = len(input) - i
+	}
+
+	if _, err := SolveOptimal(input); err == nil {
+		t.Error("Expected error for input larger than MaxOptimalSize")
+	}
+}
+
+func TestEncodeDecodeState(t *testing.T) {
+	a := []int{3, 0, 5}
+	b := []int{1, 4, 2, 6}
+
+	key := encodeState(a, b)
+	gotA, gotB := decodeState(key, 7)
+
+	if len(gotA) != len(a) || len(gotB) != len(b) {
+		t.Fatalf("Expected sizes %d/%d, got %d/%d", len(a), len(b), len(gotA), len(gotB))
+	}
+	for i := range a {
+		if gotA[i] != a[i] {
+			t.Errorf("Expected a[%d] = %d, got %d", i, a[i], gotA[i])
+		}
+	}
+	for i := range b {
+		if gotB[i] != b[i] {
+			t.Errorf("Expected b[%d] = %d, got %d", i, b[i], gotB[i])
+		}
+	}
+}
+
+func TestSolveUsesOptimalForSmallInputs(t *testing.T) {
+	input := []int{3, 1, 6, 2, 5, 4}
+	expected, _ := SolveOptimal(input)
+
+	ops := NewSolver(input).Solve()
+	if len(ops) != len(expected) {
+		t.Errorf("Expected Solve to use %d operations, got %d", len(expected), len(ops))
+	}
+}
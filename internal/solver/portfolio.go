@@ -0,0 +1,179 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"push-swap/internal/operations"
+	"push-swap/internal/optimizer"
+	"push-swap/internal/stack"
+	"time"
+)
+
+// PortfolioEntry is the outcome of one strategy in a portfolio run
+type PortfolioEntry struct {
+	Strategy   string
+	Operations []operations.Operation // the verified, optimized program; nil on failure
+	Err        error                  // why there is no program: unfinished, panicked or invalid
+	Duration   time.Duration          // time spent until the strategy finished
+}
+
+// PortfolioResult is the shortest program found by SolvePortfolio
+type PortfolioResult struct {
+	Operations []operations.Operation
+	Strategy   string           // strategy that produced Operations
+	Entries    []PortfolioEntry // every strategy that was run, in registry order
+}
+
+// SolvePortfolio runs every registered strategy that supports the input
+// size concurrently, each on its own clone of the input, verifies their
+// programs and returns the shortest. Ties go to the strategy registered
+// first, so the result only depends on which strategies finish in time.
+//
+// A budget greater than zero bounds the wall-clock time, as does ctx.
+// When either ends, strategies implementing ContextStrategy, which
+// includes every built-in one, are cancelled and stop at their next check;
+// any other strategy keeps running in its goroutine until it returns, but
+// its result is discarded. Both are reported as unfinished. An error is
+// returned only if no strategy produced a valid program.
+func SolvePortfolio(ctx context.Context, input []int, budget time.Duration) (*PortfolioResult, error) {
+	return solvePortfolio(ctx, input, budget, Strategies())
+}
+
+// solvePortfolio is SolvePortfolio over the given strategies, in the order
+// that breaks ties
+func solvePortfolio(ctx context.Context, input []int, budget time.Duration, candidates []Strategy) (*PortfolioResult, error) {
+	if budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
+	// Cancels the strategies still running once a result is returned
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var strategies []Strategy
+	for _, st := range candidates {
+		if Supports(st, len(input)) {
+			strategies = append(strategies, st)
+		}
+	}
+	if len(strategies) == 0 {
+		return nil, fmt.Errorf("no strategy can sort %d elements", len(input))
+	}
+
+	// Buffered so that abandoned strategies can still deliver and exit
+	done := make(chan finishedEntry, len(strategies))
+	base := stack.NewStack(input)
+	for i, st := range strategies {
+		a := base.Clone()
+		go func() {
+			done <- finishedEntry{i, runPortfolioEntry(ctx, st, a, input)}
+		}()
+	}
+
+	result := &PortfolioResult{Entries: make([]PortfolioEntry, len(strategies))}
+	complete := collectEntries(ctx, done, result.Entries)
+
+	for i, st := range strategies {
+		if !complete[i] {
+			result.Entries[i] = PortfolioEntry{Strategy: st.Name(), Err: ctx.Err()}
+			continue
+		}
+		entry := result.Entries[i]
+		if entry.Err == nil && (result.Operations == nil || len(entry.Operations) < len(result.Operations)) {
+			result.Operations = entry.Operations
+			result.Strategy = entry.Strategy
+		}
+	}
+
+	if result.Operations == nil {
+		if err := ctx.Err(); err != nil {
+			return result, fmt.Errorf("no strategy finished: %w", err)
+		}
+		return result, errors.New("no strategy produced a valid program")
+	}
+	return result, nil
+}
+
+// finishedEntry is the entry of the strategy at index once it returned
+type finishedEntry struct {
+	index int
+	entry PortfolioEntry
+}
+
+// collectEntries stores the entries received from done into entries until
+// every strategy finished or ctx is done, and reports which ones arrived.
+// Entries already delivered when ctx ends are kept, so that a strategy
+// that finished in time is never reported as unfinished.
+func collectEntries(ctx context.Context, done <-chan finishedEntry, entries []PortfolioEntry) []bool {
+	complete := make([]bool, len(entries))
+	store := func(f finishedEntry) {
+		entries[f.index] = f.entry
+		complete[f.index] = true
+	}
+
+	for remaining := len(entries); remaining > 0; remaining-- {
+		select {
+		case f := <-done:
+			store(f)
+		case <-ctx.Done():
+			for ; remaining > 0; remaining-- {
+				select {
+				case f := <-done:
+					store(f)
+				default:
+					return complete
+				}
+			}
+			return complete
+		}
+	}
+	return complete
+}
+
+// runPortfolioEntry solves a with st, cancelled by ctx if st supports it,
+// and verifies the program against input, turning a panic in the strategy
+// into an error
+func runPortfolioEntry(ctx context.Context, st Strategy, a *stack.Stack, input []int) (entry PortfolioEntry) {
+	entry.Strategy = st.Name()
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			entry = PortfolioEntry{Strategy: st.Name(), Err: fmt.Errorf("strategy %s panicked: %v", st.Name(), r)}
+		}
+		entry.Duration = time.Since(start)
+	}()
+
+	var ops []operations.Operation
+	if cst, ok := st.(ContextStrategy); ok {
+		var err error
+		if ops, err = cst.SolveContext(ctx, a, stack.NewEmptyStack()); err != nil {
+			entry.Err = err
+			return entry
+		}
+	} else {
+		ops = st.Solve(a, stack.NewEmptyStack())
+	}
+
+	ops = optimizer.Optimize(ops)
+	if err := Verify(input, ops); err != nil {
+		entry.Err = fmt.Errorf("strategy %s: %w", st.Name(), err)
+		return entry
+	}
+	entry.Operations = ops
+	return entry
+}
+
+// Verify replays a program on input and checks that it sorts it
+func Verify(input []int, program []operations.Operation) error {
+	stackA := stack.NewStack(input)
+	stackB := stack.NewEmptyStack()
+	if err := operations.ExecuteOperations(stackA, stackB, program); err != nil {
+		return err
+	}
+	if !stackA.IsSorted() || !stackB.IsEmpty() {
+		return fmt.Errorf("program does not sort %v", input)
+	}
+	return nil
+}
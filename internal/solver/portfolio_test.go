@@ -0,0 +1,275 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"push-swap/internal/operations"
+	"push-swap/internal/stack"
+	"strings"
+	"testing"
+	"time"
+)
+
+// funcStrategy is a test strategy for a single input size
+type funcStrategy struct {
+	name  string
+	size  int
+	solve func(a, b *stack.Stack) []operations.Operation
+}
+
+func (st funcStrategy) Name() string { return st.name }
+func (st funcStrategy) MinSize() int { return st.size }
+func (st funcStrategy) MaxSize() int { return st.size }
+
+func (st funcStrategy) Solve(a, b *stack.Stack) []operations.Operation {
+	return st.solve(a, b)
+}
+
+// ctxFuncStrategy is a funcStrategy that can be cancelled
+type ctxFuncStrategy struct {
+	funcStrategy
+	solveContext func(ctx context.Context, a, b *stack.Stack) ([]operations.Operation, error)
+}
+
+func (st ctxFuncStrategy) SolveContext(ctx context.Context, a, b *stack.Stack) ([]operations.Operation, error) {
+	return st.solveContext(ctx, a, b)
+}
+
+// slowStrategies returns turk and a strategy for 9 elements that blocks
+// until the returned channel is closed, which happens when the test ends
+func slowStrategies(t *testing.T) ([]Strategy, chan struct{}) {
+	turk, _ := Lookup(StrategyTurk)
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	slow := funcStrategy{name: "test-slow", size: 9, solve: func(a, b *stack.Stack) []operations.Operation {
+		<-release
+		return turk.Solve(a, b)
+	}}
+	return []Strategy{turk, slow}, release
+}
+
+// entryFor returns the portfolio entry of the named strategy
+func entryFor(t *testing.T, result *PortfolioResult, name string) PortfolioEntry {
+	for _, entry := range result.Entries {
+		if entry.Strategy == name {
+			return entry
+		}
+	}
+	t.Fatalf("No entry for strategy %s", name)
+	return PortfolioEntry{}
+}
+
+func TestSolvePortfolioPicksShortest(t *testing.T) {
+	rng := rand.New(rand.NewSource(24))
+	for _, size := range []int{5, 12, 100} {
+		input := rng.Perm(size)
+
+		result, err := SolvePortfolio(context.Background(), input, 0)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !validateSolution(input, result.Operations) {
+			t.Fatalf("Portfolio solution for %v should result in sorted stack", input)
+		}
+
+		for _, entry := range result.Entries {
+			if entry.Err != nil {
+				t.Errorf("Strategy %s failed: %v", entry.Strategy, entry.Err)
+			}
+			if len(entry.Operations) < len(result.Operations) {
+				t.Errorf("Strategy %s found %d ops, shorter than the chosen %d",
+					entry.Strategy, len(entry.Operations), len(result.Operations))
+			}
+		}
+	}
+}
+
+func TestSolvePortfolioTieBreak(t *testing.T) {
+	input := rand.New(rand.NewSource(8)).Perm(6)
+
+	first, err := SolvePortfolio(context.Background(), input, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// auto is registered first and finds the optimal program for 6
+	// elements, so it wins every tie
+	if first.Strategy != StrategyAuto {
+		t.Errorf("Expected %s to win the tie, got %s", StrategyAuto, first.Strategy)
+	}
+
+	for i := 0; i < 5; i++ {
+		again, _ := SolvePortfolio(context.Background(), input, 0)
+		if again.Strategy != first.Strategy || len(again.Operations) != len(first.Operations) {
+			t.Fatalf("Expected the same result on every run, got %s (%d ops) and %s (%d ops)",
+				first.Strategy, len(first.Operations), again.Strategy, len(again.Operations))
+		}
+	}
+}
+
+func TestSolvePortfolioBudget(t *testing.T) {
+	strategies, _ := slowStrategies(t)
+	input := rand.New(rand.NewSource(9)).Perm(9)
+
+	start := time.Now()
+	result, err := solvePortfolio(context.Background(), input, 50*time.Millisecond, strategies)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the budget to stop the portfolio, took %v", elapsed)
+	}
+	if !validateSolution(input, result.Operations) {
+		t.Fatal("Portfolio solution should result in sorted stack")
+	}
+
+	slow := entryFor(t, result, "test-slow")
+	if !errors.Is(slow.Err, context.DeadlineExceeded) || slow.Operations != nil {
+		t.Errorf("Expected test-slow to be unfinished, got %+v", slow)
+	}
+}
+
+func TestSolvePortfolioContext(t *testing.T) {
+	strategies, _ := slowStrategies(t)
+	input := rand.New(rand.NewSource(10)).Perm(9)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	result, err := solvePortfolio(ctx, input, 0, strategies)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if slow := entryFor(t, result, "test-slow"); !errors.Is(slow.Err, context.Canceled) {
+		t.Errorf("Expected test-slow to be cancelled, got %v", slow.Err)
+	}
+
+	// Nothing can finish once the context is already cancelled before the
+	// slow strategy is released
+	_, err = solvePortfolio(ctx, []int{9, 8, 7, 6, 5, 4, 3, 2, 1}, 0, strategies)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected no error or context.Canceled, got %v", err)
+	}
+}
+
+func TestSolvePortfolioCancelsContextStrategies(t *testing.T) {
+	turk, _ := Lookup(StrategyTurk)
+	stopped := make(chan struct{})
+	waiting := ctxFuncStrategy{
+		funcStrategy: funcStrategy{name: "test-waiting", size: 9},
+		solveContext: func(ctx context.Context, a, b *stack.Stack) ([]operations.Operation, error) {
+			defer close(stopped)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	input := rand.New(rand.NewSource(12)).Perm(9)
+
+	result, err := solvePortfolio(context.Background(), input, 50*time.Millisecond, []Strategy{turk, waiting})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Strategy != StrategyTurk {
+		t.Errorf("Expected %s to win, got %s", StrategyTurk, result.Strategy)
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the context-aware strategy to stop once the portfolio returned")
+	}
+}
+
+func TestBuiltinStrategiesStopWhenCancelled(t *testing.T) {
+	input := rand.New(rand.NewSource(13)).Perm(20000)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, name := range []string{StrategyChunk, StrategyChunkAuto, StrategyTurk, StrategyRadix, StrategyLIS} {
+		t.Run(name, func(t *testing.T) {
+			st, _ := Lookup(name)
+			cst, ok := st.(ContextStrategy)
+			if !ok {
+				t.Fatalf("Expected %s to implement ContextStrategy", name)
+			}
+
+			start := time.Now()
+			ops, err := cst.SolveContext(ctx, stack.NewStack(input), stack.NewEmptyStack())
+			if !errors.Is(err, context.Canceled) || ops != nil {
+				t.Errorf("Expected context.Canceled and no program, got %v and %d ops", err, len(ops))
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Expected %s to stop early, took %v", name, elapsed)
+			}
+		})
+	}
+}
+
+func TestSolvePortfolioRejectsBrokenStrategies(t *testing.T) {
+	turk, _ := Lookup(StrategyTurk)
+	strategies := []Strategy{
+		turk,
+		funcStrategy{name: "test-panic", size: 11, solve: func(a, b *stack.Stack) []operations.Operation {
+			panic("broken strategy")
+		}},
+		funcStrategy{name: "test-invalid", size: 11, solve: func(a, b *stack.Stack) []operations.Operation {
+			return []operations.Operation{operations.RA}
+		}},
+	}
+	input := rand.New(rand.NewSource(11)).Perm(11)
+
+	result, err := solvePortfolio(context.Background(), input, 0, strategies)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !validateSolution(input, result.Operations) {
+		t.Fatal("Portfolio solution should result in sorted stack")
+	}
+
+	if e := entryFor(t, result, "test-panic"); e.Err == nil || !strings.Contains(e.Err.Error(), "panicked") {
+		t.Errorf("Expected test-panic to report its panic, got %v", e.Err)
+	}
+	if e := entryFor(t, result, "test-invalid"); e.Err == nil || e.Operations != nil {
+		t.Errorf("Expected test-invalid to be rejected, got %+v", e)
+	}
+	if strings.HasPrefix(result.Strategy, "test-") {
+		t.Errorf("Expected a working strategy to win, got %s", result.Strategy)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	input := []int{2, 1, 3}
+	if err := Verify(input, []operations.Operation{operations.SA}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := Verify(input, []operations.Operation{operations.RA}); err == nil {
+		t.Error("Expected error for a program that does not sort")
+	}
+	if err := Verify(input, []operations.Operation{operations.PA}); err == nil {
+		t.Error("Expected error for a program that fails to execute")
+	}
+}
+
+func TestCollectEntriesKeepsDeliveredAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// With the context already done, select would pick at random between
+	// the delivered entries and ctx.Done
+	for trial := 0; trial < 100; trial++ {
+		done := make(chan finishedEntry, 3)
+		done <- finishedEntry{0, PortfolioEntry{Strategy: "first"}}
+		done <- finishedEntry{2, PortfolioEntry{Strategy: "third"}}
+
+		entries := make([]PortfolioEntry, 3)
+		complete := collectEntries(ctx, done, entries)
+		if !complete[0] || complete[1] || !complete[2] {
+			t.Fatalf("Expected the delivered entries 0 and 2 to be complete, got %v", complete)
+		}
+		if entries[0].Strategy != "first" || entries[2].Strategy != "third" {
+			t.Fatalf("Expected the delivered entries to be stored, got %+v", entries)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package solver
+
+import (
+	"math/bits"
+	"push-swap/internal/operations"
+)
+
+// autoTurkMaxSize is the largest input the auto strategy sorts with the
+// Turk algorithm. The two need about as many operations at 3000 numbers,
+// beyond which radix sort needs fewer, so larger inputs use radix sort.
+const autoTurkMaxSize = 3000
+
+// solveRadix is an LSD binary radix sort on ranks. For every bit, from
+// the least significant, it pushes the elements whose bit is 0 to B,
+// rotates the others, and pushes B back. Its operation count depends only
+// on the input size, about n*log2(n) plus the pushes, which makes it a
+// predictable baseline and a fallback for very large inputs.
+func (s *Solver) solveRadix() {
+	size := s.stackA.Size()
+
+	ranks := s.createRanks()
+	s.applyRanks(ranks)
+
+	for bit := 0; bit < bits.Len(uint(size-1)); bit++ {
+		if s.stackA.IsSorted() || s.cancelled() {
+			break
+		}
+
+		for i := 0; i < size; i++ {
+			top, _ := s.stackA.Top()
+			if top>>bit&1 == 0 {
+				s.executeAndRecord(operations.PB)
+			} else {
+				s.executeAndRecord(operations.RA)
+			}
+		}
+
+		for !s.stackB.IsEmpty() {
+			s.executeAndRecord(operations.PA)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package solver
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+)
+
+func TestSolveRadix(t *testing.T) {
+	rng := rand.New(rand.NewSource(21))
+	for _, size := range []int{2, 3, 5, 17, 100, 500} {
+		for trial := 0; trial < 3; trial++ {
+			input := rng.Perm(size)
+			for i := range input {
+				input[i] = input[i]*7 - 300
+			}
+
+			solver := NewSolver(input)
+			solver.solveRadix()
+			ops := solver.operations
+
+			if !validateSolution(input, ops) {
+				t.Fatalf("Solution for %v should result in sorted stack", input)
+			}
+
+			// Every pass moves each element once and pushes back at most n
+			maxOps := 2 * size * bits.Len(uint(size-1))
+			if len(ops) > maxOps {
+				t.Errorf("Solution for %d elements uses %d operations, expected <= %d", size, len(ops), maxOps)
+			}
+		}
+	}
+}
+
+func TestSolveRadixIsInputIndependent(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	counts := make(map[int]bool)
+	for trial := 0; trial < 5; trial++ {
+		solver := NewSolver(rng.Perm(256))
+		solver.solveRadix()
+		counts[len(solver.operations)] = true
+	}
+
+	// 256 ranks need 8 passes of 256 moves, plus 128 pa per pass
+	if len(counts) != 1 || !counts[8*(256+128)] {
+		t.Errorf("Expected every input to take %d operations, got %v", 8*(256+128), counts)
+	}
+}
+
+func TestAutoTurkMaxSizeIsCrossover(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+	tests := []struct {
+		name    string
+		size    int
+		shorter string
+	}{
+		{"Below threshold", autoTurkMaxSize * 2 / 3, StrategyTurk},
+		{"Above threshold", autoTurkMaxSize * 4 / 3, StrategyRadix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := rng.Perm(tt.size)
+			counts := make(map[string]int)
+			for _, name := range []string{StrategyTurk, StrategyRadix} {
+				s, err := NewSolverWithStrategy(input, name)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				counts[name] = len(s.Solve())
+			}
+
+			other := StrategyTurk
+			if tt.shorter == StrategyTurk {
+				other = StrategyRadix
+			}
+			if counts[tt.shorter] >= counts[other] {
+				t.Errorf("Expected %s to be shorter than %s for %d elements, got %d and %d ops",
+					tt.shorter, other, tt.size, counts[tt.shorter], counts[other])
+			}
+		})
+	}
+}
+
+func TestAutoUsesRadixForLargeInputs(t *testing.T) {
+	input := rand.New(rand.NewSource(5)).Perm(autoTurkMaxSize + 1)
+
+	auto := NewSolver(input).Solve()
+	radix, err := NewSolverWithStrategy(input, StrategyRadix)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(auto) != len(radix.Solve()) {
+		t.Errorf("Expected auto to match radix above %d elements", autoTurkMaxSize)
+	}
+	if !validateSolution(input, auto) {
+		t.Error("Solution should result in sorted stack")
+	}
+}
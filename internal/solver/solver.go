@@ -1,7 +1,10 @@
 package solver
 
 import (
+	"context"
+	"fmt"
 	"push-swap/internal/operations"
+	"push-swap/internal/optimizer"
 	"push-swap/internal/stack"
 )
 
@@ -9,39 +12,87 @@ type Solver struct {
 	stackA     *stack.Stack
 	stackB     *stack.Stack
 	operations []operations.Operation
+	strategy   Strategy
+	// ctx stops the long loops of the strategies early once it is done,
+	// leaving the stacks unsorted
+	ctx context.Context
 }
 
 func NewSolver(input []int) *Solver {
+	st, _ := Lookup(DefaultStrategy)
 	return &Solver{
 		stackA:     stack.NewStack(input),
 		stackB:     stack.NewEmptyStack(),
 		operations: make([]operations.Operation, 0),
+		strategy:   st,
+		ctx:        context.Background(),
 	}
 }
 
+// NewSolverWithStrategy creates a solver that uses the named strategy.
+// It fails if the strategy is unknown or cannot sort an input of this size.
+func NewSolverWithStrategy(input []int, name string) (*Solver, error) {
+	st, err := Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	if !Supports(st, len(input)) {
+		return nil, fmt.Errorf("strategy %s cannot sort %d elements", name, len(input))
+	}
+	
+	s := NewSolver(input)
+	s.strategy = st
+	return s, nil
+}
+
 func (s *Solver) Solve() []operations.Operation {
 	if s.stackA.IsSorted() {
 		return s.operations
 	}
 	
+	ops := s.strategy.Solve(s.stackA, s.stackB)
+	s.operations = append(s.operations, optimizer.Optimize(ops)...)
+	return s.operations
+}
+
+// cancelled reports whether the solver's context is done. Strategies
+// check it between pushes and return early when it is.
+func (s *Solver) cancelled() bool {
+	return s.ctx.Err() != nil
+}
+
+// solveAuto picks an algorithm based on the size of stack A
+func (s *Solver) solveAuto() {
 	size := s.stackA.Size()
 	
 	switch {
 	case size <= 1:
-		return s.operations
+		return
+	case size <= autoOptimalSize:
+		s.solveOptimal()
+	case size <= autoTurkMaxSize:
+		s.solveLargeTurk()
+	default:
+		s.solveRadix()
+	}
+}
+
+// solveSmall uses hand-written heuristics for up to six elements
+func (s *Solver) solveSmall() {
+	size := s.stackA.Size()
+	
+	switch {
+	case size <= 1:
+		return
 	case size == 2:
 		s.solveTwo()
 	case size == 3:
 		s.solveThree()
 	case size <= 5:
 		s.solveFive()
-	case size == 6:
-		s.solveSixImproved()
 	default:
-		s.solveLargeOptimized()
+		s.solveSixImproved()
 	}
-	
-	return s.operations
 }
 
 func (s *Solver) executeAndRecord(op operations.Operation) {
@@ -125,72 +176,6 @@ func (s *Solver) solveFourInPlace() {
 	s.executeAndRecord(operations.PA)
 }
 
-// solveLargeOptimized uses a simple but reliable push-all then insert-back approach
-func (s *Solver) solveLargeOptimized() {
-	size := s.stackA.Size()
-	
-	// Convert to ranks for easier handling
-	ranks := s.createRanks()
-	s.applyRanks(ranks)
-	
-	// Calculate chunk size
-	var chunkSize int
-	if size <= 100 {
-		chunkSize = 20
-	} else if size <= 500 {
-		chunkSize = 30
-	} else {
-		chunkSize = 45
-	}
-	
-	numChunks := (size + chunkSize - 1) / chunkSize
-	
-	// Push all elements to B in chunks
-	for chunk := 0; chunk < numChunks; chunk++ {
-		minRange := chunk * chunkSize
-		maxRange := (chunk + 1) * chunkSize
-		if maxRange > size {
-			maxRange = size
-		}
-		
-		// Push all elements in this range
-		elementsInChunk := maxRange - minRange
-		rotations := 0
-		
-		for elementsInChunk > 0 && rotations < size*2 { // Safety limit
-			if s.stackA.IsEmpty() {
-				break
-			}
-			
-			top, _ := s.stackA.Top()
-			
-			if top >= minRange && top < maxRange {
-				s.executeAndRecord(operations.PB)
-				elementsInChunk--
-				
-				// Smart rotation in B
-				if s.stackB.Size() > 1 {
-					midRange := (minRange + maxRange) / 2
-					if top < midRange {
-						s.executeAndRecord(operations.RB)
-					}
-				}
-			} else {
-				s.executeAndRecord(operations.RA)
-			}
-			
-			rotations++
-		}
-	}
-	
-	// Push everything back from B to A (largest first)
-	for s.stackB.Size() > 0 {
-		maxPos := s.findMaxPosition(s.stackB)
-		s.moveToTopOptimized(s.stackB, maxPos, false)
-		s.executeAndRecord(operations.PA)
-	}
-}
-
 func (s *Solver) findMinPosition(st *stack.Stack) int {
 	if st.IsEmpty() {
 		return -1
@@ -319,6 +304,10 @@ func (s *Solver) quickSort(arr []int, low, high int) {
 }
 
 func (s *Solver) partition(arr []int, low, high int) int {
+	// Pivot on the middle element so that sorted and reverse sorted runs,
+	// common in large inputs, do not degrade to quadratic time
+	mid := low + (high-low)/2
+	arr[mid], arr[high] = arr[high], arr[mid]
 	pivot := arr[high]
 	i := low - 1
 	
@@ -0,0 +1,149 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"push-swap/internal/operations"
+	"push-swap/internal/stack"
+)
+
+// Names of the built-in strategies
+const (
+	StrategyAuto      = "auto"
+	StrategySmall     = "small"
+	StrategyOptimal   = "optimal"
+	StrategyChunk     = "chunk"
+	StrategyChunkAuto = "chunk-auto"
+	StrategyTurk      = "turk"
+	StrategyRadix     = "radix"
+	StrategyLIS       = "lis"
+)
+
+// DefaultStrategy is used by NewSolver
+const DefaultStrategy = StrategyAuto
+
+// Strategy is a sorting algorithm that can be registered and selected by name
+type Strategy interface {
+	// Name identifies the strategy in the registry
+	Name() string
+	// MinSize is the smallest input the strategy can sort
+	MinSize() int
+	// MaxSize is the largest input the strategy can sort, 0 meaning unbounded
+	MaxSize() int
+	// Solve sorts a, using b as scratch space, and returns the operations
+	// it performed. Both stacks are left in their final state.
+	Solve(a, b *stack.Stack) []operations.Operation
+}
+
+// ContextStrategy is a Strategy that can be cancelled. SolveContext works
+// like Solve but checks ctx as it goes and, once ctx is done, stops early
+// and returns the error of ctx, leaving the stacks in an unspecified state.
+// Every built-in strategy implements it.
+type ContextStrategy interface {
+	Strategy
+	SolveContext(ctx context.Context, a, b *stack.Stack) ([]operations.Operation, error)
+}
+
+// Supports reports whether st can sort an input of the given size
+func Supports(st Strategy, size int) bool {
+	if size < st.MinSize() {
+		return false
+	}
+	return st.MaxSize() == 0 || size <= st.MaxSize()
+}
+
+// solverStrategy adapts one of the Solver's solve methods to Strategy
+type solverStrategy struct {
+	name    string
+	minSize int
+	maxSize int
+	solve   func(s *Solver)
+}
+
+func (st *solverStrategy) Name() string { return st.name }
+func (st *solverStrategy) MinSize() int { return st.minSize }
+func (st *solverStrategy) MaxSize() int { return st.maxSize }
+
+func (st *solverStrategy) Solve(a, b *stack.Stack) []operations.Operation {
+	ops, _ := st.SolveContext(context.Background(), a, b)
+	return ops
+}
+
+func (st *solverStrategy) SolveContext(ctx context.Context, a, b *stack.Stack) ([]operations.Operation, error) {
+	s := &Solver{
+		stackA:     a,
+		stackB:     b,
+		operations: make([]operations.Operation, 0),
+		ctx:        ctx,
+	}
+	if !a.IsSorted() {
+		st.solve(s)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.operations, nil
+}
+
+var (
+	registry      = make(map[string]Strategy)
+	registryOrder []string
+)
+
+func init() {
+	builtins := []Strategy{
+		&solverStrategy{name: StrategyAuto, solve: (*Solver).solveAuto},
+		&solverStrategy{name: StrategySmall, maxSize: 6, solve: (*Solver).solveSmall},
+		&solverStrategy{name: StrategyOptimal, maxSize: MaxOptimalSize, solve: (*Solver).solveOptimal},
+		&solverStrategy{name: StrategyChunk, solve: (*Solver).solveLargeOptimized},
+		&solverStrategy{name: StrategyChunkAuto, solve: (*Solver).solveChunkAuto},
+		&solverStrategy{name: StrategyTurk, minSize: 3, solve: (*Solver).solveLargeTurk},
+		&solverStrategy{name: StrategyRadix, solve: (*Solver).solveRadix},
+		&solverStrategy{name: StrategyLIS, solve: (*Solver).solveLIS},
+	}
+	for _, st := range builtins {
+		if err := Register(st); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Register adds a strategy to the registry. Names must be unique.
+func Register(st Strategy) error {
+	name := st.Name()
+	if name == "" {
+		return fmt.Errorf("strategy name must not be empty")
+	}
+	if _, exists := registry[name]; exists {
+		return fmt.Errorf("strategy already registered: %s", name)
+	}
+	registry[name] = st
+	registryOrder = append(registryOrder, name)
+	return nil
+}
+
+// Lookup returns the registered strategy with the given name
+func Lookup(name string) (Strategy, error) {
+	st, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy: %s", name)
+	}
+	return st, nil
+}
+
+// Strategies returns every registered strategy in registration order
+func Strategies() []Strategy {
+	result := make([]Strategy, 0, len(registryOrder))
+	for _, name := range registryOrder {
+		result = append(result, registry[name])
+	}
+	return result
+}
+
+// StrategyNames returns the names of every registered strategy in
+// registration order
+func StrategyNames() []string {
+	names := make([]string, len(registryOrder))
+	copy(names, registryOrder)
+	return names
+}
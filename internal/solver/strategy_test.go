@@ -0,0 +1,120 @@
+package solver
+
+import (
+	"math/rand"
+	"push-swap/internal/operations"
+	"push-swap/internal/stack"
+	"slices"
+	"testing"
+)
+
+// reverseStrategy is a deliberately naive strategy used to exercise Register
+type reverseStrategy struct{}
+
+func (reverseStrategy) Name() string { return "test-reverse" }
+func (reverseStrategy) MinSize() int { return 2 }
+func (reverseStrategy) MaxSize() int { return 2 }
+
+func (reverseStrategy) Solve(a, b *stack.Stack) []operations.Operation {
+	operations.ExecuteOperation(a, b, operations.SA)
+	return []operations.Operation{operations.SA}
+}
+
+// registerForTest registers st and removes it from the registry again
+// when the test ends, so that it never leaks into other tests
+func registerForTest(t *testing.T, st Strategy) {
+	t.Helper()
+	if err := Register(st); err != nil {
+		t.Fatalf("Unexpected error registering strategy: %v", err)
+	}
+	t.Cleanup(func() {
+		delete(registry, st.Name())
+		registryOrder = slices.DeleteFunc(registryOrder, func(name string) bool { return name == st.Name() })
+	})
+}
+
+func TestBuiltinStrategiesRegistered(t *testing.T) {
+	for _, name := range []string{StrategyAuto, StrategySmall, StrategyOptimal, StrategyChunk, StrategyChunkAuto, StrategyTurk, StrategyRadix, StrategyLIS} {
+		st, err := Lookup(name)
+		if err != nil {
+			t.Errorf("Expected strategy %s to be registered: %v", name, err)
+			continue
+		}
+		if st.Name() != name {
+			t.Errorf("Expected name %s, got %s", name, st.Name())
+		}
+	}
+
+	names := StrategyNames()
+	if len(names) < 5 || names[0] != StrategyAuto {
+		t.Errorf("Expected auto to be registered first, got %v", names)
+	}
+	if len(Strategies()) != len(names) {
+		t.Errorf("Strategies and StrategyNames disagree: %d vs %d", len(Strategies()), len(names))
+	}
+}
+
+func TestLookupUnknownStrategy(t *testing.T) {
+	if _, err := Lookup("does-not-exist"); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	registerForTest(t, reverseStrategy{})
+
+	if err := Register(reverseStrategy{}); err == nil {
+		t.Error("Expected error when registering a duplicate name")
+	}
+
+	s, err := NewSolverWithStrategy([]int{2, 1}, "test-reverse")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ops := s.Solve()
+	if !validateSolution([]int{2, 1}, ops) {
+		t.Errorf("Registered strategy should sort, got %v", ops)
+	}
+}
+
+func TestSupports(t *testing.T) {
+	turk, _ := Lookup(StrategyTurk)
+
+	if Supports(turk, 2) {
+		t.Error("Turk strategy should not support 2 elements")
+	}
+	if !Supports(turk, 10000) {
+		t.Error("Turk strategy should have no upper bound")
+	}
+}
+
+func TestNewSolverWithStrategy(t *testing.T) {
+	if _, err := NewSolverWithStrategy([]int{3, 2, 1}, "does-not-exist"); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+
+	if _, err := NewSolverWithStrategy([]int{2, 1}, StrategyTurk); err == nil {
+		t.Error("Expected error for unsupported input size")
+	}
+}
+
+func TestStrategiesSortRandomInputs(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+
+	for _, st := range Strategies() {
+		for _, size := range []int{2, 3, 5, 6, 12, 100} {
+			if !Supports(st, size) {
+				continue
+			}
+			input := rng.Perm(size)
+			s, err := NewSolverWithStrategy(input, st.Name())
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", st.Name(), err)
+			}
+			ops := s.Solve()
+			if !validateSolution(input, ops) {
+				t.Errorf("%s: solution for %d elements should result in sorted stack", st.Name(), size)
+			}
+		}
+	}
+}
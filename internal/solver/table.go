@@ -0,0 +1,137 @@
+package solver
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"push-swap/internal/operations"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:generate go run ../../cmd/tablegen -o optimal_table.txt
+
+// TableMaxSize is the largest input covered by the embedded table of
+// optimal programs
+const TableMaxSize = 6
+
+// optimalTableData holds one line per permutation of 0..n-1 for every
+// n <= TableMaxSize: the ranks written as digits, then the optimal
+// operations separated by spaces
+//
+//go:embed optimal_table.txt
+var optimalTableData string
+
+var (
+	optimalTableOnce sync.Once
+	optimalTable     map[string][]operations.Operation
+)
+
+// lookupOptimal returns the optimal program for a permutation of 0..n-1
+// from the embedded table
+func lookupOptimal(ranks []int) ([]operations.Operation, bool) {
+	optimalTableOnce.Do(func() {
+		table, err := parseOptimalTable(strings.NewReader(optimalTableData))
+		if err != nil {
+			panic(fmt.Sprintf("embedded optimal table is corrupt: %v", err))
+		}
+		optimalTable = table
+	})
+
+	ops, ok := optimalTable[tableKey(ranks)]
+	return ops, ok
+}
+
+// WriteOptimalTable searches every permutation of up to maxSize elements
+// and writes the results in the format of the embedded table
+func WriteOptimalTable(w io.Writer, maxSize int) error {
+	if maxSize > MaxOptimalSize {
+		return fmt.Errorf("optimal table supports at most %d elements, got %d", MaxOptimalSize, maxSize)
+	}
+
+	bw := bufio.NewWriter(w)
+	for n := 1; n <= maxSize; n++ {
+		for _, perm := range permutations(n) {
+			ops := searchOptimal(perm)
+
+			line := make([]string, 0, len(ops)+1)
+			line = append(line, tableKey(perm))
+			for _, op := range ops {
+				line = append(line, string(op))
+			}
+			if _, err := fmt.Fprintln(bw, strings.Join(line, " ")); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// parseOptimalTable reads a table written by WriteOptimalTable
+func parseOptimalTable(r io.Reader) (map[string][]operations.Operation, error) {
+	table := make(map[string][]operations.Operation)
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		ops := make([]operations.Operation, 0, len(fields)-1)
+		for _, field := range fields[1:] {
+			op, ok := operations.ValidOperations[field]
+			if !ok {
+				return nil, fmt.Errorf("line %d: invalid operation: %s", lineNum, field)
+			}
+			ops = append(ops, op)
+		}
+		table[fields[0]] = ops
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// tableKey writes the ranks of a permutation as a string of digits
+func tableKey(ranks []int) string {
+	var sb strings.Builder
+	for _, r := range ranks {
+		sb.WriteString(strconv.Itoa(r))
+	}
+	return sb.String()
+}
+
+// permutations returns every permutation of 0..n-1 in lexicographic order
+func permutations(n int) [][]int {
+	var result [][]int
+	perm := make([]int, 0, n)
+	used := make([]bool, n)
+
+	var build func()
+	build = func() {
+		if len(perm) == n {
+			result = append(result, append([]int{}, perm...))
+			return
+		}
+		for v := 0; v < n; v++ {
+			if used[v] {
+				continue
+			}
+			used[v] = true
+			perm = append(perm, v)
+			build()
+			perm = perm[:len(perm)-1]
+			used[v] = false
+		}
+	}
+	build()
+
+	return result
+}
@@ -0,0 +1,98 @@
+package solver
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOptimalTableCoversAllPermutations(t *testing.T) {
+	for n := 1; n <= TableMaxSize; n++ {
+		for _, perm := range permutations(n) {
+			ops, ok := lookupOptimal(perm)
+			if !ok {
+				t.Fatalf("Table has no entry for %v", perm)
+			}
+
+			if !validateSolution(perm, ops) {
+				t.Errorf("Table entry %v for %v should result in sorted stack", ops, perm)
+			}
+
+			// Searching every 6-element permutation is slow, the smaller
+			// sizes are enough to catch a stale table
+			if n <= 5 {
+				if searched := searchOptimal(perm); len(searched) != len(ops) {
+					t.Errorf("Table entry for %v has %d operations, search found %d", perm, len(ops), len(searched))
+				}
+			}
+		}
+	}
+}
+
+func TestLookupOptimalMissing(t *testing.T) {
+	if _, ok := lookupOptimal([]int{6, 5, 4, 3, 2, 1, 0}); ok {
+		t.Error("Table should not contain 7-element permutations")
+	}
+}
+
+func TestWriteAndParseOptimalTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteOptimalTable(&buf, 3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	table, err := parseOptimalTable(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// 1! + 2! + 3! permutations
+	if len(table) != 9 {
+		t.Errorf("Expected 9 entries, got %d", len(table))
+	}
+
+	if ops := table["210"]; len(ops) != 2 {
+		t.Errorf("Expected 2 operations for 210, got %v", ops)
+	}
+}
+
+func TestWriteOptimalTableTooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteOptimalTable(&buf, MaxOptimalSize+1); err == nil {
+		t.Error("Expected error for table larger than MaxOptimalSize")
+	}
+}
+
+func TestParseOptimalTableInvalidOperation(t *testing.T) {
+	if _, err := parseOptimalTable(strings.NewReader("10 sa\n01 xx\n")); err == nil {
+		t.Error("Expected error for invalid operation")
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	perms := permutations(4)
+	if len(perms) != 24 {
+		t.Fatalf("Expected 24 permutations, got %d", len(perms))
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range perms {
+		seen[tableKey(p)] = true
+	}
+	if len(seen) != 24 {
+		t.Errorf("Expected 24 distinct permutations, got %d", len(seen))
+	}
+
+	if tableKey(perms[0]) != "0123" || tableKey(perms[23]) != "3210" {
+		t.Errorf("Expected lexicographic order, got %v ... %v", perms[0], perms[23])
+	}
+}
+
+func BenchmarkSolveTable(b *testing.B) {
+	input := []int{6, 5, 4, 3, 2, 1}
+
+	for i := 0; i < b.N; i++ {
+		solver := NewSolver(input)
+		solver.Solve()
+	}
+}
@@ -0,0 +1,46 @@
+package solver
+
+import (
+	"fmt"
+	"push-swap/internal/operations"
+	"push-swap/internal/stack"
+)
+
+// Step is the state of both stacks at one point of a program
+type Step struct {
+	Index     int                  // number of operations executed so far
+	Operation operations.Operation // operation that produced this state, empty for the initial state
+	StackA    []int
+	StackB    []int
+}
+
+// Trace replays a program, typically the result of Solve, on input. It
+// calls fn with the initial state and then with the state after every
+// operation, stopping early if fn returns false. An operation that fails
+// to execute ends the trace with its error.
+func Trace(input []int, ops []operations.Operation, fn func(Step) bool) error {
+	stackA := stack.NewStack(input)
+	stackB := stack.NewEmptyStack()
+
+	if !fn(Step{StackA: stackA.ToSlice(), StackB: stackB.ToSlice()}) {
+		return nil
+	}
+
+	for i, op := range ops {
+		if err := operations.ExecuteOperation(stackA, stackB, op); err != nil {
+			return fmt.Errorf("operation %d (%s): %w", i+1, op, err)
+		}
+
+		step := Step{
+			Index:     i + 1,
+			Operation: op,
+			StackA:    stackA.ToSlice(),
+			StackB:    stackB.ToSlice(),
+		}
+		if !fn(step) {
+			return nil
+		}
+	}
+
+	return nil
+}
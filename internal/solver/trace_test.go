@@ -0,0 +1,73 @@
+package solver
+
+import (
+	"push-swap/internal/operations"
+	"reflect"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	input := []int{3, 1, 2}
+	ops := []operations.Operation{operations.PB, operations.RA, operations.PA}
+
+	var steps []Step
+	err := Trace(input, ops, func(step Step) bool {
+		steps = append(steps, step)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []Step{
+		{Index: 0, StackA: []int{3, 1, 2}, StackB: []int{}},
+		{Index: 1, Operation: operations.PB, StackA: []int{1, 2}, StackB: []int{3}},
+		{Index: 2, Operation: operations.RA, StackA: []int{2, 1}, StackB: []int{3}},
+		{Index: 3, Operation: operations.PA, StackA: []int{3, 2, 1}, StackB: []int{}},
+	}
+	if !reflect.DeepEqual(steps, expected) {
+		t.Errorf("Expected steps %v, got %v", expected, steps)
+	}
+}
+
+func TestTraceStopsEarly(t *testing.T) {
+	input := []int{5, 4, 3, 2, 1}
+	ops := NewSolver(input).Solve()
+
+	calls := 0
+	err := Trace(input, ops, func(step Step) bool {
+		calls++
+		return step.Index < 2
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls before stopping, got %d", calls)
+	}
+}
+
+func TestTraceInvalidProgram(t *testing.T) {
+	err := Trace([]int{1, 2}, []operations.Operation{operations.PA}, func(Step) bool { return true })
+	if err == nil {
+		t.Error("Expected error when pushing from empty stack B")
+	}
+}
+
+func TestTraceMatchesSolve(t *testing.T) {
+	input := []int{10, 3, 7, 1, 9, 2, 8, 4, 6, 5}
+	ops := NewSolver(input).Solve()
+
+	var last Step
+	Trace(input, ops, func(step Step) bool {
+		last = step
+		return true
+	})
+
+	if last.Index != len(ops) {
+		t.Errorf("Expected last step %d, got %d", len(ops), last.Index)
+	}
+	if !isSorted(last.StackA) || len(last.StackB) != 0 {
+		t.Errorf("Expected final state to be sorted, got A=%v B=%v", last.StackA, last.StackB)
+	}
+}
@@ -0,0 +1,168 @@
+package solver
+
+import (
+	"push-swap/internal/operations"
+	"sort"
+)
+
+// insertionMove describes the rotations needed to bring an element of B and
+// its target slot in A to the top of their stacks at the same time.
+type insertionMove struct {
+	rotA int // ra when positive, rra when negative
+	rotB int // rb when positive, rrb when negative
+	cost int
+}
+
+// solveLargeTurk pushes everything but three elements to B, sorts the three
+// left in A and then repeatedly inserts the cheapest element of B back into
+// its place in A, sharing rr/rrr whenever both stacks rotate the same way.
+func (s *Solver) solveLargeTurk() {
+	size := s.stackA.Size()
+
+	ranks := s.createRanks()
+	s.applyRanks(ranks)
+
+	// Push the lower half first and sink it to the bottom of B so that the
+	// upper half ends up near the top, which keeps later insertions short
+	mid := size / 2
+	for s.stackA.Size() > 3 {
+		if s.cancelled() {
+			return
+		}
+		top, _ := s.stackA.Top()
+		s.executeAndRecord(operations.PB)
+		if top < mid && s.stackB.Size() > 1 {
+			s.executeAndRecord(operations.RB)
+		}
+	}
+
+	s.solveThree()
+
+	for !s.stackB.IsEmpty() {
+		if s.cancelled() {
+			return
+		}
+		move := s.cheapestInsertion()
+		s.applyInsertion(move)
+		s.executeAndRecord(operations.PA)
+	}
+
+	minPos := s.findMinPosition(s.stackA)
+	s.moveToTopOptimized(s.stackA, minPos, true)
+}
+
+// cheapestInsertion returns the move with the lowest combined rotation cost
+// over every element currently in B
+func (s *Solver) cheapestInsertion() insertionMove {
+	a := s.stackA.ToSlice()
+	b := s.stackB.ToSlice()
+	minPos := minIndex(a)
+
+	best := insertionMove{cost: -1}
+	for i, value := range b {
+		// Bringing index i of B to the top alone costs at least this much,
+		// so elements deep in B are skipped once a cheap move is known
+		if best.cost >= 0 && min(i, len(b)-i) >= best.cost {
+			continue
+		}
+		j := insertionIndex(a, minPos, value)
+		move := combineRotations(j, len(a), i, len(b))
+		if best.cost < 0 || move.cost < best.cost {
+			best = move
+		}
+		// Nothing beats a move that needs no rotation at all
+		if best.cost == 0 {
+			break
+		}
+	}
+
+	return best
+}
+
+// applyInsertion executes the rotations described by move
+func (s *Solver) applyInsertion(move insertionMove) {
+	for _, op := range rotationRun(move) {
+		s.executeAndRecord(op)
+	}
+}
+
+// rotationRun returns the rotations described by move, sharing rr and rrr
+// where both stacks turn the same way
+func rotationRun(move insertionMove) []operations.Operation {
+	var run []operations.Operation
+	for move.rotA > 0 && move.rotB > 0 {
+		run = append(run, operations.RR)
+		move.rotA--
+		move.rotB--
+	}
+	for move.rotA < 0 && move.rotB < 0 {
+		run = append(run, operations.RRR)
+		move.rotA++
+		move.rotB++
+	}
+	for ; move.rotA > 0; move.rotA-- {
+		run = append(run, operations.RA)
+	}
+	for ; move.rotA < 0; move.rotA++ {
+		run = append(run, operations.RRA)
+	}
+	for ; move.rotB > 0; move.rotB-- {
+		run = append(run, operations.RB)
+	}
+	for ; move.rotB < 0; move.rotB++ {
+		run = append(run, operations.RRB)
+	}
+	return run
+}
+
+// insertionIndex returns the index in a (a rotated ascending sequence
+// whose minimum is at minPos) that value must be pushed on top of: the
+// smallest element greater than value, or the minimum when value is
+// greater than everything in a. It binary searches the rotated sequence.
+func insertionIndex(a []int, minPos, value int) int {
+	n := len(a)
+	k := sort.Search(n, func(k int) bool { return a[(minPos+k)%n] > value })
+	if k == n {
+		return minPos
+	}
+	return (minPos + k) % n
+}
+
+// minIndex returns the index of the smallest element of a, 0 if a is empty
+func minIndex(a []int) int {
+	minPos := 0
+	for i, v := range a {
+		if v < a[minPos] {
+			minPos = i
+		}
+	}
+	return minPos
+}
+
+// combineRotations picks the cheapest way to bring index i of a stack of
+// size sizeA and index j of a stack of size sizeB to the top together.
+func combineRotations(i, sizeA, j, sizeB int) insertionMove {
+	upA, downA := i, sizeA-i
+	upB, downB := j, sizeB-j
+	if i == 0 {
+		downA = 0
+	}
+	if j == 0 {
+		downB = 0
+	}
+
+	candidates := []insertionMove{
+		{rotA: upA, rotB: upB, cost: max(upA, upB)},
+		{rotA: -downA, rotB: -downB, cost: max(downA, downB)},
+		{rotA: upA, rotB: -downB, cost: upA + downB},
+		{rotA: -downA, rotB: upB, cost: downA + upB},
+	}
+
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.cost < best.cost {
+			best = c
+		}
+	}
+	return best
+}
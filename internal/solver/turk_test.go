@@ -0,0 +1,129 @@
+package solver
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSolveLargeTurk(t *testing.T) {
+	tests := []struct {
+		name   string
+		size   int
+		maxOps int
+	}{
+		{"7 elements", 7, 30},
+		{"10 elements", 10, 50},
+		{"100 elements", 100, 700},
+		{"500 elements", 500, 5500},
+	}
+
+	rng := rand.New(rand.NewSource(42))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for trial := 0; trial < 5; trial++ {
+				input := rng.Perm(tt.size)
+				solver := NewSolver(input)
+				solver.solveLargeTurk()
+				ops := solver.operations
+
+				if !validateSolution(input, ops) {
+					t.Fatalf("Solution for %v should result in sorted stack", input)
+				}
+
+				if len(ops) > tt.maxOps {
+					t.Errorf("Solution uses %d operations, expected <= %d", len(ops), tt.maxOps)
+				}
+			}
+		})
+	}
+}
+
+func TestSolveLargeTurkReverseSorted(t *testing.T) {
+	input := make([]int, 100)
+	for i := range input {
+		input[i] = 100 - i
+	}
+
+	solver := NewSolver(input)
+	solver.solveLargeTurk()
+
+	if !validateSolution(input, solver.operations) {
+		t.Error("Solution should result in sorted stack")
+	}
+}
+
+func TestInsertionIndex(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        []int
+		value    int
+		expected int
+	}{
+		{"Between elements", []int{1, 3, 5}, 2, 1},
+		{"Greater than all", []int{1, 3, 5}, 6, 0},
+		{"Smaller than all", []int{1, 3, 5}, 0, 0},
+		{"Rotated stack", []int{5, 7, 1, 3}, 4, 0},
+		{"Rotated wraps to min", []int{5, 7, 1, 3}, 8, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := insertionIndex(tt.a, minIndex(tt.a), tt.value); got != tt.expected {
+				t.Errorf("Expected index %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestInsertionIndexMatchesLinearScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(21))
+	for trial := 0; trial < 500; trial++ {
+		// A rotated ascending sequence of distinct even values, searched
+		// for odd values and for values beyond both ends
+		n := 1 + rng.Intn(30)
+		sorted := make([]int, n)
+		for i := range sorted {
+			sorted[i] = 2 * i
+		}
+		shift := rng.Intn(n)
+		a := append(append([]int{}, sorted[shift:]...), sorted[:shift]...)
+
+		for value := -1; value <= 2*n; value += 2 {
+			expected := -1
+			for i, v := range a {
+				if v > value && (expected < 0 || v < a[expected]) {
+					expected = i
+				}
+			}
+			if expected < 0 {
+				expected = minIndex(a)
+			}
+			if got := insertionIndex(a, minIndex(a), value); got != expected {
+				t.Fatalf("Inserting %d into %v: expected index %d, got %d", value, a, expected, got)
+			}
+		}
+	}
+}
+
+func TestCombineRotations(t *testing.T) {
+	tests := []struct {
+		name         string
+		i, sizeA     int
+		j, sizeB     int
+		expectedCost int
+	}{
+		{"Both on top", 0, 5, 0, 5, 0},
+		{"Shared rr", 2, 10, 3, 10, 3},
+		{"Shared rrr", 8, 10, 9, 10, 2},
+		{"Opposite directions", 1, 10, 9, 10, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			move := combineRotations(tt.i, tt.sizeA, tt.j, tt.sizeB)
+			if move.cost != tt.expectedCost {
+				t.Errorf("Expected cost %d, got %d", tt.expectedCost, move.cost)
+			}
+		})
+	}
+}
@@ -2,9 +2,15 @@ package stack
 
 import "fmt"
 
-// Stack represents a stack data structure
+// minCapacity is the smallest buffer allocated once a stack needs to grow
+const minCapacity = 8
+
+// Stack represents a stack data structure. It is stored as a circular
+// deque so that pushing, popping and rotating are all O(1).
 type Stack struct {
-	data []int
+	data []int // ring buffer, len(data) is the capacity
+	head int   // index of the top element in data
+	size int   // number of elements in the stack
 }
 
 // NewStack creates a new stack with the given data
@@ -12,7 +18,7 @@ func NewStack(data []int) *Stack {
 	// Copy the slice to avoid external modifications
 	stackData := make([]int, len(data))
 	copy(stackData, data)
-	return &Stack{data: stackData}
+	return &Stack{data: stackData, size: len(data)}
 }
 
 // NewEmptyStack creates a new empty stack
@@ -20,14 +26,41 @@ func NewEmptyStack() *Stack {
 	return &Stack{data: make([]int, 0)}
 }
 
+// index converts a position from the top into an index in the ring buffer
+func (s *Stack) index(pos int) int {
+	return (s.head + pos) % len(s.data)
+}
+
+// grow doubles the capacity of the ring buffer, unwrapping it so that the
+// top element is at index 0
+func (s *Stack) grow() {
+	capacity := 2 * len(s.data)
+	if capacity < minCapacity {
+		capacity = minCapacity
+	}
+	data := make([]int, capacity)
+	s.copyTo(data)
+	s.data = data
+	s.head = 0
+}
+
+// copyTo copies the elements from top to bottom into dst
+func (s *Stack) copyTo(dst []int) {
+	if s.size == 0 {
+		return
+	}
+	n := copy(dst, s.data[s.head:min(s.head+s.size, len(s.data))])
+	copy(dst[n:s.size], s.data)
+}
+
 // Size returns the number of elements in the stack
 func (s *Stack) Size() int {
-	return len(s.data)
+	return s.size
 }
 
 // IsEmpty returns true if the stack is empty
 func (s *Stack) IsEmpty() bool {
-	return len(s.data) == 0
+	return s.size == 0
 }
 
 // Top returns the top element without removing it
@@ -35,12 +68,17 @@ func (s *Stack) Top() (int, error) {
 	if s.IsEmpty() {
 		return 0, fmt.Errorf("stack is empty")
 	}
-	return s.data[0], nil
+	return s.data[s.head], nil
 }
 
 // Push adds an element to the top of the stack
 func (s *Stack) Push(value int) {
-	s.data = append([]int{value}, s.data...)
+	if s.size == len(s.data) {
+		s.grow()
+	}
+	s.head = (s.head - 1 + len(s.data)) % len(s.data)
+	s.data[s.head] = value
+	s.size++
 }
 
 // Pop removes and returns the top element
@@ -48,30 +86,74 @@ func (s *Stack) Pop() (int, error) {
 	if s.IsEmpty() {
 		return 0, fmt.Errorf("stack is empty")
 	}
-	value := s.data[0]
-	s.data = s.data[1:]
+	value := s.data[s.head]
+	s.head = s.index(1)
+	s.size--
 	return value, nil
 }
 
+// Bottom returns the bottom element without removing it
+func (s *Stack) Bottom() (int, error) {
+	if s.IsEmpty() {
+		return 0, fmt.Errorf("stack is empty")
+	}
+	return s.data[s.index(s.size-1)], nil
+}
+
+// Swap exchanges the top two elements. It reports whether the stack
+// changed, which it does not with fewer than 2 elements.
+func (s *Stack) Swap() bool {
+	if s.size < 2 {
+		return false
+	}
+	first, second := s.head, s.index(1)
+	s.data[first], s.data[second] = s.data[second], s.data[first]
+	return true
+}
+
+// Rotate moves the top element to the bottom of the stack. It reports
+// whether the stack changed, which it does not with fewer than 2 elements.
+func (s *Stack) Rotate() bool {
+	if s.size < 2 {
+		return false
+	}
+	value := s.data[s.head]
+	s.head = s.index(1)
+	s.data[s.index(s.size-1)] = value
+	return true
+}
+
+// ReverseRotate moves the bottom element to the top of the stack. It reports
+// whether the stack changed, which it does not with fewer than 2 elements.
+func (s *Stack) ReverseRotate() bool {
+	if s.size < 2 {
+		return false
+	}
+	value := s.data[s.index(s.size-1)]
+	s.head = (s.head - 1 + len(s.data)) % len(s.data)
+	s.data[s.head] = value
+	return true
+}
+
 // At returns the element at the given index (0 is top)
 func (s *Stack) At(index int) (int, error) {
-	if index < 0 || index >= len(s.data) {
+	if index < 0 || index >= s.size {
 		return 0, fmt.Errorf("index out of bounds")
 	}
-	return s.data[index], nil
+	return s.data[s.index(index)], nil
 }
 
 // ToSlice returns a copy of the stack data
 func (s *Stack) ToSlice() []int {
-	result := make([]int, len(s.data))
-	copy(result, s.data)
+	result := make([]int, s.size)
+	s.copyTo(result)
 	return result
 }
 
 // IsSorted returns true if the stack is sorted in ascending order
 func (s *Stack) IsSorted() bool {
-	for i := 0; i < len(s.data)-1; i++ {
-		if s.data[i] > s.data[i+1] {
+	for i := 0; i < s.size-1; i++ {
+		if s.data[s.index(i)] > s.data[s.index(i+1)] {
 			return false
 		}
 	}
@@ -80,10 +162,10 @@ func (s *Stack) IsSorted() bool {
 
 // String returns a string representation of the stack
 func (s *Stack) String() string {
-	return fmt.Sprintf("%v", s.data)
+	return fmt.Sprintf("%v", s.ToSlice())
 }
 
 // Clone creates a deep copy of the stack
 func (s *Stack) Clone() *Stack {
-	return NewStack(s.data)
-}
\ No newline at end of file
+	return NewStack(s.ToSlice())
+}
@@ -180,4 +180,167 @@ func TestClone(t *testing.T) {
 	if original.Size() == clone.Size() {
 		t.Error("Clone should be independent of original")
 	}
-}
\ No newline at end of file
+}
+
+func TestRotate(t *testing.T) {
+	s := NewStack([]int{1, 2, 3, 4})
+	
+	if !s.Rotate() {
+		t.Error("Rotate should report a change for 4 elements")
+	}
+	
+	expected := []int{2, 3, 4, 1}
+	for i, exp := range expected {
+		val, _ := s.At(i)
+		if val != exp {
+			t.Errorf("Expected s[%d] = %d after Rotate, got %d", i, exp, val)
+		}
+	}
+	
+	// Rotating size times restores the original order
+	for i := 0; i < 3; i++ {
+		s.Rotate()
+	}
+	if s.String() != "[1 2 3 4]" {
+		t.Errorf("Expected [1 2 3 4] after a full cycle, got %s", s)
+	}
+}
+
+func TestReverseRotate(t *testing.T) {
+	s := NewStack([]int{1, 2, 3, 4})
+	
+	if !s.ReverseRotate() {
+		t.Error("ReverseRotate should report a change for 4 elements")
+	}
+	
+	expected := []int{4, 1, 2, 3}
+	for i, exp := range expected {
+		val, _ := s.At(i)
+		if val != exp {
+			t.Errorf("Expected s[%d] = %d after ReverseRotate, got %d", i, exp, val)
+		}
+	}
+}
+
+func TestRotateSmallStack(t *testing.T) {
+	single := NewStack([]int{42})
+	if single.Rotate() || single.ReverseRotate() {
+		t.Error("Rotating a single element should report no change")
+	}
+	
+	empty := NewEmptyStack()
+	if empty.Rotate() || empty.ReverseRotate() {
+		t.Error("Rotating an empty stack should report no change")
+	}
+}
+
+func TestRingBufferWrapAround(t *testing.T) {
+	// Mix pushes, pops and rotations so the ring buffer wraps and grows,
+	// checking every step against a plain slice
+	s := NewStack([]int{1, 2, 3})
+	model := []int{1, 2, 3}
+	
+	for i := 0; i < 200; i++ {
+		switch i % 5 {
+		case 0, 1:
+			s.Push(100 + i)
+			model = append([]int{100 + i}, model...)
+		case 2:
+			s.Rotate()
+			model = append(model[1:], model[0])
+		case 3:
+			s.ReverseRotate()
+			model = append([]int{model[len(model)-1]}, model[:len(model)-1]...)
+			s.Rotate()
+			model = append(model[1:], model[0])
+		case 4:
+			val, _ := s.Pop()
+			if val != model[0] {
+				t.Fatalf("Step %d: expected pop %d, got %d", i, model[0], val)
+			}
+			model = model[1:]
+		}
+		
+		actual := s.ToSlice()
+		if len(actual) != len(model) {
+			t.Fatalf("Step %d: expected size %d, got %d", i, len(model), len(actual))
+		}
+		for j := range model {
+			if actual[j] != model[j] {
+				t.Fatalf("Step %d: expected %v, got %v", i, model, actual)
+			}
+		}
+	}
+}
+
+func BenchmarkRotate(b *testing.B) {
+	data := make([]int, 500)
+	for i := range data {
+		data[i] = i
+	}
+	s := NewStack(data)
+	
+	for i := 0; i < b.N; i++ {
+		s.Rotate()
+	}
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	data := make([]int, 500)
+	for i := range data {
+		data[i] = i
+	}
+	s := NewStack(data)
+	
+	for i := 0; i < b.N; i++ {
+		s.Push(i)
+		s.Pop()
+	}
+}
+
+func TestSwap(t *testing.T) {
+	s := NewStack([]int{1, 2, 3})
+	
+	if !s.Swap() {
+		t.Error("Swap should report a change for 3 elements")
+	}
+	
+	if s.String() != "[2 1 3]" {
+		t.Errorf("Expected [2 1 3] after Swap, got %s", s)
+	}
+	
+	single := NewStack([]int{42})
+	if single.Swap() {
+		t.Error("Swapping a single element should report no change")
+	}
+}
+
+func TestSwapAfterWrapAround(t *testing.T) {
+	// Rotate so the top two elements straddle the end of the ring buffer
+	s := NewStack([]int{1, 2, 3, 4})
+	s.ReverseRotate()
+	s.Swap()
+	
+	if s.String() != "[1 4 2 3]" {
+		t.Errorf("Expected [1 4 2 3], got %s", s)
+	}
+}
+
+func TestBottom(t *testing.T) {
+	s := NewStack([]int{1, 2, 3})
+	
+	bottom, err := s.Bottom()
+	if err != nil || bottom != 3 {
+		t.Errorf("Expected bottom 3, got %d (error: %v)", bottom, err)
+	}
+	
+	s.Rotate()
+	bottom, _ = s.Bottom()
+	if bottom != 1 {
+		t.Errorf("Expected bottom 1 after Rotate, got %d", bottom)
+	}
+	
+	if _, err := NewEmptyStack().Bottom(); err == nil {
+		t.Error("Expected error when getting bottom of empty stack")
+	}
+}
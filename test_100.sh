@@ -44,17 +44,19 @@ OPERATIONS=$(./push-swap "$ARG")
 COUNT=$(echo "$OPERATIONS" | wc -l)
 
 echo "Operations count: $COUNT"
-
-if [ $COUNT -lt 700 ]; then
-    echo -e "${GREEN}✓ PASS${NC}: Less than 700 operations ($COUNT)"
-else
-    echo -e "${RED}✗ FAIL${NC}: More than 700 operations ($COUNT)"
-fi
 echo
 
-# Validate with checker
+# Validate and grade with checker against the subject thresholds (< 700)
 echo -e "${BLUE}Validating with checker...${NC}"
-VALIDATION=$(echo "$OPERATIONS" | ./checker "$ARG")
+RESULT=$(echo "$OPERATIONS" | ./checker -grade spec "$ARG")
+VALIDATION=$(echo "$RESULT" | head -n 1)
+GRADE=$(echo "$RESULT" | sed -n 2p)
+
+if [[ "$GRADE" == PASS* ]]; then
+    echo -e "${GREEN}✓ PASS${NC}: $GRADE"
+else
+    echo -e "${RED}✗ FAIL${NC}: $GRADE"
+fi
 
 if [ "$VALIDATION" = "OK" ]; then
     echo -e "${GREEN}✓ PASS${NC}: Checker validation OK"
@@ -71,6 +73,6 @@ echo "========================================"
 echo "Numbers: 100"
 echo "Operations: $COUNT"
 echo "Target: < 700"
-echo "Status: $([ $COUNT -lt 700 ] && echo -e "${GREEN}PASS${NC}" || echo -e "${RED}FAIL${NC}")"
+echo "Status: $([[ "$GRADE" == PASS* ]] && echo -e "${GREEN}PASS${NC}" || echo -e "${RED}FAIL${NC}")"
 echo "Validation: $VALIDATION"
 echo "========================================"
\ No newline at end of file